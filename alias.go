@@ -0,0 +1,87 @@
+package nid
+
+import (
+	"fmt"
+	"slices"
+)
+
+// WithAliases sets the legacy names of the [Naming], e.g. after renaming "user_profile" to "profile".
+// The [NID]s with the legacy names match the [Naming.Is] and are accepted by the [Naming.Parse],
+// the [Naming.Column] and the [Naming.ParseExternal]. The aliases must follow the [Naming] name rule.
+func WithAliases(names ...string) Option {
+	return func(cfg *config) error {
+		if len(names) == 0 {
+			return fmt.Errorf("%w: at least one alias is required", ErrInvalidOption)
+		}
+
+		cfg.aliases = append(cfg.aliases, names...)
+
+		return nil
+	}
+}
+
+// WithCanonicalAliases makes the [Naming] rewrite the legacy names set by the [WithAliases] to the canonical name
+// whenever it parses, scans or stores the [NID]. The optional report function is called for every rewritten [NID].
+func WithCanonicalAliases(report func(legacy, canonical NID)) Option {
+	return func(cfg *config) error {
+		cfg.canon = true
+		cfg.report = report
+
+		return nil
+	}
+}
+
+// Aliases returns the legacy names of the [Naming].
+func (n Naming) Aliases() []string {
+	if n.cfg == nil {
+		return nil
+	}
+
+	return slices.Clone(n.cfg.aliases)
+}
+
+// Canonical returns the [NID] with the canonical name of the [Naming] if it has a legacy name.
+// It reports whether the [NID] was rewritten. Other [NID]s are returned as is.
+func (n Naming) Canonical(id NID) (NID, bool) {
+	n.initialized()
+
+	if id.Empty() || id.name == n.name || !n.Is(id) {
+		return id, false
+	}
+
+	return n.Update(id), true
+}
+
+// Migrate rewrites the legacy names of the [NID]s in place to the canonical name of the [Naming].
+// It returns the original [NID]s that were rewritten, e.g. to update the stored values.
+func (n Naming) Migrate(ids []NID) []NID {
+	var legacy []NID
+
+	for i, id := range ids {
+		if canonical, ok := n.Canonical(id); ok {
+			legacy = append(legacy, id)
+			ids[i] = canonical
+		}
+	}
+
+	return legacy
+}
+
+// match returns true if the name is the name or a legacy name of the [Naming].
+func (n Naming) match(name string) bool {
+	return name == n.name || (n.cfg != nil && slices.Contains(n.cfg.aliases, name))
+}
+
+// canonicalize rewrites the legacy name of the [NID] if the [WithCanonicalAliases] is set.
+func (n Naming) canonicalize(id NID) NID {
+	if n.cfg == nil || !n.cfg.canon {
+		return id
+	}
+
+	canonical, ok := n.Canonical(id)
+	if ok && n.cfg.report != nil {
+		n.cfg.report(id, canonical)
+	}
+
+	return canonical
+}
@@ -0,0 +1,274 @@
+package nid_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestWithAliases(t *testing.T) {
+	tt := []struct {
+		name    string
+		input   string
+		opts    []nid.Option
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			input: "profile",
+			opts:  []nid.Option{nid.WithAliases("user_profile", "account_profile")},
+		},
+		{
+			name:    "empty",
+			input:   "profile",
+			opts:    []nid.Option{nid.WithAliases()},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "invalid_alias",
+			input:   "profile",
+			opts:    []nid.Option{nid.WithAliases("User-Profile")},
+			wantErr: nid.ErrInvalidName,
+		},
+		{
+			name:    "alias_equals_name",
+			input:   "profile",
+			opts:    []nid.Option{nid.WithAliases("profile")},
+			wantErr: nid.ErrInvalidName,
+		},
+		{
+			name:    "duplicate_alias",
+			input:   "profile",
+			opts:    []nid.Option{nid.WithAliases("user_profile"), nid.WithAliases("user_profile")},
+			wantErr: nid.ErrInvalidName,
+		},
+		{
+			name:  "alias_with_rule",
+			input: "profile",
+			opts:  []nid.Option{nid.WithNameRule(nid.KebabCase), nid.WithAliases("user-profile")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nid.NewNaming(tc.input, tc.opts...)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Errorf("NewNaming() err = %v; want = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamingAliasesParse(t *testing.T) {
+	var (
+		base    = nid.MustParseBase("000034o1ibe7u02570ak9evj9s")
+		legacy  = nid.MustParse("user_profile_000034o1ibe7u02570ak9evj9s")
+		current = nid.MustParse("profile_000034o1ibe7u02570ak9evj9s")
+	)
+
+	tt := []struct {
+		name    string
+		naming  nid.Naming
+		str     string
+		want    nid.NID
+		wantErr error
+	}{
+		{
+			name:   "canonical",
+			naming: nid.MustNaming("profile", nid.WithAliases("user_profile")),
+			str:    "profile_000034o1ibe7u02570ak9evj9s",
+			want:   current,
+		},
+		{
+			name:   "legacy",
+			naming: nid.MustNaming("profile", nid.WithAliases("user_profile")),
+			str:    "user_profile_000034o1ibe7u02570ak9evj9s",
+			want:   legacy,
+		},
+		{
+			name:   "legacy_canonicalized",
+			naming: nid.MustNaming("profile", nid.WithAliases("user_profile"), nid.WithCanonicalAliases(nil)),
+			str:    "user_profile_000034o1ibe7u02570ak9evj9s",
+			want:   current,
+		},
+		{
+			name:   "legacy_scoped",
+			naming: mustScope(nid.MustNaming("profile", nid.WithAliases("user_profile")), "acme"),
+			str:    "acme.user_profile_000034o1ibe7u02570ak9evj9s",
+			want:   mustScope(nid.MustNaming("user_profile"), "acme").Apply(base),
+		},
+		{
+			name:    "unknown",
+			naming:  nid.MustNaming("profile", nid.WithAliases("user_profile")),
+			str:     "account_000034o1ibe7u02570ak9evj9s",
+			wantErr: nid.ErrFailedParse,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.naming.Parse(tc.str)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Fatalf("Naming.Parse() err = %v; want = %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("Naming.Parse() = %v; want = %v", got, tc.want)
+			}
+
+			if tc.wantErr == nil && !tc.naming.Is(got) {
+				t.Errorf("Naming.Is(%v) = false; want = true", got)
+			}
+		})
+	}
+}
+
+func TestNamingCanonical(t *testing.T) {
+	naming := nid.MustNaming("profile", nid.WithAliases("user_profile"))
+
+	tt := []struct {
+		name   string
+		id     nid.NID
+		want   nid.NID
+		wantOK bool
+	}{
+		{
+			name:   "legacy",
+			id:     nid.MustParse("user_profile_000034o1ibe7u02570ak9evj9s"),
+			want:   nid.MustParse("profile_000034o1ibe7u02570ak9evj9s"),
+			wantOK: true,
+		},
+		{
+			name:   "legacy_namespace",
+			id:     nid.MustParse("acme.user_profile_000034o1ibe7u02570ak9evj9s"),
+			want:   nid.MustParse("acme.profile_000034o1ibe7u02570ak9evj9s"),
+			wantOK: true,
+		},
+		{
+			name: "canonical",
+			id:   nid.MustParse("profile_000034o1ibe7u02570ak9evj9s"),
+			want: nid.MustParse("profile_000034o1ibe7u02570ak9evj9s"),
+		},
+		{
+			name: "other",
+			id:   nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+			want: nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+		},
+		{
+			name: "empty",
+			id:   nid.NID{},
+			want: nid.NID{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := naming.Canonical(tc.id)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Naming.Canonical() = %v, %v; want = %v, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestNamingMigrate(t *testing.T) {
+	naming := nid.MustNaming("profile", nid.WithAliases("user_profile"))
+
+	ids := []nid.NID{
+		nid.MustParse("user_profile_000034o1ibe7u02570ak9evj9s"),
+		nid.MustParse("profile_000034o1ibe7u02570ak9evjfs"),
+		nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+	}
+
+	legacy := naming.Migrate(ids)
+
+	want := []nid.NID{
+		nid.MustParse("profile_000034o1ibe7u02570ak9evj9s"),
+		nid.MustParse("profile_000034o1ibe7u02570ak9evjfs"),
+		nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+	}
+
+	for i := range ids {
+		if ids[i] != want[i] {
+			t.Errorf("Naming.Migrate()[%d] = %v; want = %v", i, ids[i], want[i])
+		}
+	}
+
+	if len(legacy) != 1 || legacy[0] != nid.MustParse("user_profile_000034o1ibe7u02570ak9evj9s") {
+		t.Errorf("Naming.Migrate() legacy = %v", legacy)
+	}
+}
+
+func TestNamingCanonicalAliasesReport(t *testing.T) {
+	var reported []nid.NID
+
+	naming := nid.MustNaming("profile",
+		nid.WithAliases("user_profile"),
+		nid.WithCanonicalAliases(func(legacy, _ nid.NID) {
+			reported = append(reported, legacy)
+		}),
+	)
+
+	legacy := nid.MustParse("user_profile_000034o1ibe7u02570ak9evj9s")
+
+	if _, err := naming.Parse(legacy.String()); err != nil {
+		t.Fatalf("Naming.Parse() unexpected err = %v", err)
+	}
+
+	if _, err := naming.Parse("profile_000034o1ibe7u02570ak9evj9s"); err != nil {
+		t.Fatalf("Naming.Parse() unexpected err = %v", err)
+	}
+
+	value, err := naming.Column(&legacy).Value()
+	if err != nil {
+		t.Fatalf("Column.Value() unexpected err = %v", err)
+	}
+
+	if value != "profile_000034o1ibe7u02570ak9evj9s" {
+		t.Errorf("Column.Value() = %v; want canonical", value)
+	}
+
+	if len(reported) != 2 || reported[0] != legacy || reported[1] != legacy {
+		t.Errorf("reported = %v; want = [%v %v]", reported, legacy, legacy)
+	}
+}
+
+func TestRegistryAliases(t *testing.T) {
+	naming := nid.MustNaming("profile", nid.WithAliases("user_profile"))
+	registry := nid.MustRegistry(naming, nid.MustNaming("book"))
+
+	got, err := registry.Parse("user_profile_000034o1ibe7u02570ak9evj9s")
+	if err != nil {
+		t.Fatalf("Registry.Parse() unexpected err = %v", err)
+	}
+
+	if !naming.Is(got) {
+		t.Errorf("Registry.Parse() = %v; want profile", got)
+	}
+
+	if _, err := nid.NewRegistry(naming, nid.MustNaming("user_profile")); !errors.Is(err, nid.ErrInvalidName) {
+		t.Errorf("NewRegistry() err = %v; want = %v", err, nid.ErrInvalidName)
+	}
+}
+
+func TestNamingAliasesJSONSchema(t *testing.T) {
+	schema := nid.MustNaming("profile", nid.WithAliases("user_profile")).JSONSchema()
+
+	re := regexp.MustCompile(schema.Pattern)
+
+	for _, str := range []string{
+		"profile_000034o1ibe7u02570ak9evj9s",
+		"user_profile_000034o1ibe7u02570ak9evj9s",
+		"acme.user_profile_000034o1ibe7u02570ak9evj9s",
+	} {
+		if !re.MatchString(str) {
+			t.Errorf("JSONSchema().Pattern %q doesn't match %q", schema.Pattern, str)
+		}
+	}
+
+	if re.MatchString("book_000034o1ibe7u02570ak9evj9s") {
+		t.Errorf("JSONSchema().Pattern %q matches other names", schema.Pattern)
+	}
+}
@@ -2,10 +2,8 @@ package nid
 
 import (
 	"bytes"
-	"crypto/rand"
 	"database/sql/driver"
 	"encoding/base32"
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -21,22 +19,14 @@ const (
 var encoding = base32.NewEncoding(encStr).WithPadding(base32.NoPadding) //nolint:gochecknoglobals
 
 // Base of the [NID] with a time and random part.
-// The time part is 8 bytes and the random part is 8 bytes.
+// By default the time part is 8 bytes and the random part is 8 bytes, see [Layout] for the alternatives.
 // The total length is 16 bytes. The [Base] is sortable and unique.
 type Base [baseLen]byte
 
-// NewBaseAt creates a new [Base] for the given time.
-// The time is in nanoseconds.
+// NewBaseAt creates a new [Base] of the [LayoutDefault] for the given time.
+// The time is truncated to milliseconds.
 func NewBaseAt(ts time.Time) Base {
-	var dst Base
-
-	binary.BigEndian.PutUint64(dst[:timeLen], uint64(ts.UnixMilli()))
-
-	if _, err := rand.Read(dst[timeLen:]); err != nil {
-		panic(err)
-	}
-
-	return dst
+	return LayoutDefault.NewBaseAt(ts)
 }
 
 // NewBase creates a new [Base] at the current time.
@@ -66,16 +56,17 @@ func MustParseBase(src string) Base {
 	return base
 }
 
-// Unix returns the time of the [Base] in seconds.
+// UnixMilli returns the time of the [Base] in milliseconds.
 func (base Base) UnixMilli() int64 {
-	ts := binary.BigEndian.Uint64(base[:timeLen])
-
-	return int64(ts) //nolint:gosec
+	return base.Time().UnixMilli()
 }
 
-// Time returns the time of the [Base].
+// Time returns the time of the [Base] with the precision of its [Layout].
 func (base Base) Time() time.Time {
-	return time.Unix(0, base.UnixMilli()*int64(time.Millisecond))
+	units, unit := base.units()
+	perSec := int64(time.Second / unit)
+
+	return time.Unix(units/perSec, units%perSec*int64(unit))
 }
 
 // Value returns the driver value of the [Base].
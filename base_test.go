@@ -92,6 +92,7 @@ func TestNewBaseAt(t *testing.T) {
 			wantPanic: true,
 			before: func() {
 				rand.Reader = failReader{os.ErrClosed}
+				nid.ResetEntropy()
 			},
 			after: func() {
 				rand.Reader = randReader
@@ -0,0 +1,235 @@
+package nid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+const (
+	prefixLen     = 6
+	suffixLen     = baseLen - prefixLen
+	baseSetFormat = 1
+)
+
+type suffix [suffixLen]byte
+
+// BaseSet is a compressed set of the [Base]s for holding millions of identifiers in memory.
+// The [Base]s are grouped into the containers by the first 6 bytes, which is about a minute of the creation time
+// for the [LayoutDefault], so every [Base] takes only its 10 remaining bytes in a sorted container.
+// The zero value is an empty set ready to use. The [BaseSet] is not safe for concurrent modification.
+type BaseSet struct {
+	keys       []uint64
+	containers [][]suffix
+	size       int
+}
+
+// NewBaseSet creates a new [BaseSet] from the [Base]s.
+func NewBaseSet(bases ...Base) *BaseSet {
+	s := &BaseSet{}
+
+	sorted := DedupBase(slices.Clone(bases))
+	for i := 0; i < len(sorted); {
+		key := prefix(sorted[i])
+
+		j := i + 1
+		for j < len(sorted) && prefix(sorted[j]) == key {
+			j++
+		}
+
+		container := make([]suffix, 0, j-i)
+		for _, base := range sorted[i:j] {
+			container = append(container, suffixOf(base))
+		}
+
+		s.keys = append(s.keys, key)
+		s.containers = append(s.containers, container)
+		s.size += j - i
+
+		i = j
+	}
+
+	return s
+}
+
+// Len returns the number of the [Base]s in the [BaseSet].
+func (s *BaseSet) Len() int {
+	return s.size
+}
+
+// Contains returns true if the [BaseSet] contains the [Base].
+func (s *BaseSet) Contains(base Base) bool {
+	i, ok := slices.BinarySearch(s.keys, prefix(base))
+	if !ok {
+		return false
+	}
+
+	_, ok = searchSuffix(s.containers[i], suffixOf(base))
+
+	return ok
+}
+
+// Add inserts the [Base] into the [BaseSet]. It returns false if the [Base] is already in the [BaseSet].
+func (s *BaseSet) Add(base Base) bool {
+	key, sfx := prefix(base), suffixOf(base)
+
+	i, ok := slices.BinarySearch(s.keys, key)
+	if !ok {
+		s.keys = slices.Insert(s.keys, i, key)
+		s.containers = slices.Insert(s.containers, i, []suffix{sfx})
+		s.size++
+
+		return true
+	}
+
+	j, ok := searchSuffix(s.containers[i], sfx)
+	if ok {
+		return false
+	}
+
+	s.containers[i] = slices.Insert(s.containers[i], j, sfx)
+	s.size++
+
+	return true
+}
+
+// Remove deletes the [Base] from the [BaseSet]. It returns false if the [Base] is not in the [BaseSet].
+func (s *BaseSet) Remove(base Base) bool {
+	i, ok := slices.BinarySearch(s.keys, prefix(base))
+	if !ok {
+		return false
+	}
+
+	j, ok := searchSuffix(s.containers[i], suffixOf(base))
+	if !ok {
+		return false
+	}
+
+	if s.containers[i] = slices.Delete(s.containers[i], j, j+1); len(s.containers[i]) == 0 {
+		s.keys = slices.Delete(s.keys, i, i+1)
+		s.containers = slices.Delete(s.containers, i, i+1)
+	}
+
+	s.size--
+
+	return true
+}
+
+// All returns the sequence of the [Base]s of the [BaseSet] in sort order.
+func (s *BaseSet) All() iter.Seq[Base] {
+	return func(yield func(Base) bool) {
+		for i, key := range s.keys {
+			for _, sfx := range s.containers[i] {
+				if !yield(join(key, sfx)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MarshalBinary encodes the [BaseSet] as the format version, the number of the containers,
+// and for every container the delta of its key, the number of the [Base]s and their suffixes.
+func (s *BaseSet) MarshalBinary() ([]byte, error) {
+	dst := make([]byte, 0, 1+binary.MaxVarintLen64*(1+2*len(s.keys))+suffixLen*s.size)
+
+	dst = append(dst, baseSetFormat)
+	dst = binary.AppendUvarint(dst, uint64(len(s.keys)))
+
+	var prev uint64
+
+	for i, key := range s.keys {
+		dst = binary.AppendUvarint(dst, key-prev)
+		dst = binary.AppendUvarint(dst, uint64(len(s.containers[i])))
+
+		for _, sfx := range s.containers[i] {
+			dst = append(dst, sfx[:]...)
+		}
+
+		prev = key
+	}
+
+	return dst, nil
+}
+
+// UnmarshalBinary decodes the [BaseSet] encoded by the [BaseSet.MarshalBinary].
+func (s *BaseSet) UnmarshalBinary(src []byte) error {
+	if len(src) == 0 || src[0] != baseSetFormat {
+		return fmt.Errorf("%w: unknown base set format", ErrFailedParse)
+	}
+
+	r := bytes.NewReader(src[1:])
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil || count > uint64(r.Len()) {
+		return fmt.Errorf("%w: invalid base set containers count", ErrFailedParse)
+	}
+
+	dst := BaseSet{keys: make([]uint64, 0, count), containers: make([][]suffix, 0, count)}
+
+	var key uint64
+
+	for i := range count {
+		delta, err := binary.ReadUvarint(r)
+		if err != nil || (i > 0 && delta == 0) || key+delta < key || key+delta >= 1<<(prefixLen*8) {
+			return fmt.Errorf("%w: invalid base set container key", ErrFailedParse)
+		}
+
+		key += delta
+
+		size, err := binary.ReadUvarint(r)
+		if err != nil || size == 0 || size > uint64(r.Len()/suffixLen) {
+			return fmt.Errorf("%w: invalid base set container size", ErrFailedParse)
+		}
+
+		container := make([]suffix, size)
+		for j := range container {
+			_, _ = r.Read(container[j][:])
+
+			if j > 0 && bytes.Compare(container[j-1][:], container[j][:]) >= 0 {
+				return fmt.Errorf("%w: unsorted base set container", ErrFailedParse)
+			}
+		}
+
+		dst.keys = append(dst.keys, key)
+		dst.containers = append(dst.containers, container)
+		dst.size += len(container)
+	}
+
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: trailing base set data", ErrFailedParse)
+	}
+
+	*s = dst
+
+	return nil
+}
+
+func searchSuffix(container []suffix, sfx suffix) (int, bool) {
+	return slices.BinarySearchFunc(container, sfx, func(a, b suffix) int {
+		return bytes.Compare(a[:], b[:])
+	})
+}
+
+func prefix(base Base) uint64 {
+	var key [8]byte
+
+	copy(key[8-prefixLen:], base[:prefixLen])
+
+	return binary.BigEndian.Uint64(key[:])
+}
+
+func suffixOf(base Base) suffix {
+	return suffix(base[prefixLen:])
+}
+
+func join(key uint64, sfx suffix) Base {
+	var dst Base
+
+	binary.BigEndian.PutUint64(dst[:8], key<<(64-prefixLen*8))
+	copy(dst[prefixLen:], sfx[:])
+
+	return dst
+}
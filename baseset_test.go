@@ -0,0 +1,163 @@
+package nid_test
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+func newBases(count int, span time.Duration) []nid.Base {
+	ts := time.Date(2024, time.November, 6, 0, 0, 0, 0, time.UTC)
+
+	bases := make([]nid.Base, count)
+	for i := range bases {
+		bases[i] = nid.NewBaseAt(ts.Add(span * time.Duration(i) / time.Duration(count)))
+	}
+
+	return bases
+}
+
+func TestBaseSet(t *testing.T) {
+	bases := newBases(10_000, 24*time.Hour)
+	set := nid.NewBaseSet(append(bases, bases[:100]...)...)
+
+	if set.Len() != len(bases) {
+		t.Fatalf("BaseSet.Len() = %d; want = %d", set.Len(), len(bases))
+	}
+
+	for _, base := range bases {
+		if !set.Contains(base) {
+			t.Fatalf("BaseSet.Contains(%s) = false; want = true", base)
+		}
+	}
+
+	if got := slices.Collect(set.All()); !slices.Equal(got, nid.DedupBase(slices.Clone(bases))) {
+		t.Errorf("BaseSet.All() is not the sorted list of the bases")
+	}
+
+	extra := nid.NewBase()
+	if set.Contains(extra) || !set.Add(extra) || set.Add(extra) || !set.Contains(extra) {
+		t.Errorf("BaseSet.Add() doesn't add %s once", extra)
+	}
+
+	if !set.Remove(extra) || set.Remove(extra) || set.Contains(extra) || set.Len() != len(bases) {
+		t.Errorf("BaseSet.Remove() doesn't remove %s once", extra)
+	}
+
+	var empty nid.BaseSet
+	if empty.Contains(extra) || empty.Remove(extra) || !empty.Add(extra) || empty.Len() != 1 {
+		t.Errorf("zero BaseSet is not usable")
+	}
+}
+
+func TestBaseSetMarshalBinary(t *testing.T) {
+	bases := newBases(1000, time.Hour)
+	set := nid.NewBaseSet(bases...)
+
+	data, err := set.MarshalBinary()
+	if err != nil {
+		t.Fatalf("BaseSet.MarshalBinary() unexpected err = %v", err)
+	}
+
+	if limit := len(bases) * 11; len(data) > limit {
+		t.Errorf("BaseSet.MarshalBinary() len = %d; want at most %d", len(data), limit)
+	}
+
+	var got nid.BaseSet
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("BaseSet.UnmarshalBinary() unexpected err = %v", err)
+	}
+
+	if !slices.Equal(slices.Collect(got.All()), slices.Collect(set.All())) {
+		t.Errorf("BaseSet.UnmarshalBinary() doesn't match the original set")
+	}
+
+	tt := []struct {
+		name string
+		src  []byte
+	}{
+		{
+			name: "empty",
+			src:  nil,
+		},
+		{
+			name: "unknown_format",
+			src:  []byte{2, 0},
+		},
+		{
+			name: "truncated",
+			src:  data[:len(data)-1],
+		},
+		{
+			name: "trailing",
+			src:  append(slices.Clone(data), 0),
+		},
+		{
+			name: "empty_container",
+			src:  []byte{1, 1, 5, 0},
+		},
+		{
+			name: "unsorted_container",
+			src:  append([]byte{1, 1, 5, 2}, make([]byte, 20)...),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var set nid.BaseSet
+			if err := set.UnmarshalBinary(tc.src); err == nil {
+				t.Errorf("BaseSet.UnmarshalBinary() err = nil; want error")
+			}
+		})
+	}
+}
+
+func heapAlloc() uint64 {
+	var stats runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
+
+func BenchmarkBaseSetMemory(b *testing.B) {
+	bases := newBases(1_000_000, 24*time.Hour)
+
+	b.Run("base_set", func(b *testing.B) {
+		for range b.N {
+			before := heapAlloc()
+			set := nid.NewBaseSet(bases...)
+			b.ReportMetric(float64(heapAlloc()-before)/float64(set.Len()), "bytes/base")
+		}
+	})
+
+	b.Run("map", func(b *testing.B) {
+		for range b.N {
+			before := heapAlloc()
+
+			set := make(map[nid.Base]struct{})
+			for _, base := range bases {
+				set[base] = struct{}{}
+			}
+
+			b.ReportMetric(float64(heapAlloc()-before)/float64(len(set)), "bytes/base")
+		}
+	})
+}
+
+func BenchmarkBaseSetContains(b *testing.B) {
+	bases := newBases(1_000_000, 24*time.Hour)
+	set := nid.NewBaseSet(bases...)
+
+	b.ResetTimer()
+
+	for i := range b.N {
+		if !set.Contains(bases[i%len(bases)]) {
+			b.Fatal("BaseSet.Contains() = false")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package nid
+
+import (
+	"math/bits"
+	"time"
+)
+
+// NewN creates the given number of new [NID]s at the current time in strictly ascending order.
+// See [Naming.NewBatch].
+func (n Naming) NewN(count int) []NID {
+	dst := make([]NID, count)
+	n.NewBatch(dst)
+
+	return dst
+}
+
+// NewBatch fills the slice with new [NID]s at the current time in strictly ascending order.
+// The random parts of all [NID]s are read at once, which is much faster than calling the [Naming.New] in a loop.
+// To keep the order without sorting, every [NID] takes its random part from its own 1/len(dst) share of the range,
+// so a batch of 1024 [NID]s trades 10 random bits for the order.
+// With the [WithGenerator] it uses the [Generator] for every [NID] and panics if it fails.
+func (n Naming) NewBatch(dst []NID) {
+	n.initialized()
+
+	if n.cfg != nil && n.cfg.generator != nil {
+		for i := range dst {
+			dst[i] = n.New()
+		}
+
+		return
+	}
+
+	for i, base := range newBaseBatch(n.Layout(), time.Now(), len(dst)) {
+		dst[i] = NID{namespace: n.namespace, name: n.name, sep: n.sep(), base: base}
+	}
+}
+
+// newBaseBatch creates the [Base]s of the [Layout] for the given time in strictly ascending order without sorting.
+// The top 64 random bits are split into equal slots, one per [Base], and each [Base] takes a random value
+// within its slot, so the random parts are still uniform while the order is guaranteed.
+func newBaseBatch(l Layout, ts time.Time, count int) []Base {
+	var (
+		width = l.randBits()
+		top   = min(width, 64)
+		size  = int((width + 7) / 8)
+		buf   = make([]byte, size*count)
+		bases = make([]Base, count)
+	)
+
+	readEntropy(buf)
+
+	slot := slotSize(top, uint64(count))
+
+	for i := range bases {
+		var src Base
+
+		copy(src[baseLen-size:], buf[i*size:(i+1)*size])
+
+		hi, lo := src.uint128()
+		_, r := shr128(hi, lo, width-top)
+		r &= mask(top)
+
+		if slot != 0 {
+			offset, _ := bits.Mul64(r<<(64-top), slot)
+			r = uint64(i)*slot + offset //nolint:gosec
+		}
+
+		thi, tlo := shl128(0, r, width-top)
+		bases[i] = l.put(fromUint128(thi, tlo|lo&mask(width-top)), ts)
+	}
+
+	return bases
+}
+
+// slotSize returns the size of the equal slots the range of the given bits is split into.
+// Zero means the whole range for a single slot.
+func slotSize(width uint, count uint64) uint64 {
+	if count <= 1 {
+		return 0
+	} else if width == 64 {
+		slot, _ := bits.Div64(1, 0, count)
+
+		return slot
+	}
+
+	return max(1, (uint64(1)<<width)/count)
+}
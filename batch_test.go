@@ -0,0 +1,93 @@
+package nid_test
+
+import (
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNaming_NewBatch(t *testing.T) {
+	tt := []struct {
+		name   string
+		naming nid.Naming
+		count  int
+	}{
+		{
+			name:   "empty",
+			naming: nid.MustNaming("book"),
+		},
+		{
+			name:   "single",
+			naming: nid.MustNaming("book"),
+			count:  1,
+		},
+		{
+			name:   "many",
+			naming: nid.MustNaming("book"),
+			count:  10_000,
+		},
+		{
+			name:   "compact_scoped",
+			naming: mustScope(nid.MustNaming("book", nid.WithLayout(nid.LayoutCompact)), "acme"),
+			count:  1000,
+		},
+		{
+			name:   "nano",
+			naming: nid.MustNaming("book", nid.WithLayout(nid.LayoutNano)),
+			count:  1000,
+		},
+		{
+			name:   "generator",
+			naming: nid.MustNaming("book", nid.WithGenerator(nid.MustGenerator(1))),
+			count:  1000,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			before := time.Now().Truncate(time.Millisecond)
+			got := tc.naming.NewN(tc.count)
+			after := time.Now()
+
+			if len(got) != tc.count {
+				t.Fatalf("Naming.NewN() len = %d; want = %d", len(got), tc.count)
+			}
+
+			for i, id := range got {
+				if !tc.naming.Is(id) || id.Base().Layout() != tc.naming.Layout() {
+					t.Fatalf("Naming.NewN()[%d] = %v; want naming %s", i, id, tc.naming.Name())
+				}
+
+				if ts := id.Base().Time(); ts.Before(before) || ts.After(after) {
+					t.Fatalf("Naming.NewN()[%d].Time() = %v; want within [%v, %v]", i, ts, before, after)
+				}
+
+				if i > 0 && nid.Compare(got[i-1], id) >= 0 {
+					t.Fatalf("Naming.NewN()[%d] = %v; want greater than %v", i, id, got[i-1])
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNaming_New(b *testing.B) {
+	naming := nid.MustNaming("book")
+
+	for range b.N {
+		_ = naming.New()
+	}
+}
+
+func BenchmarkNaming_NewBatch(b *testing.B) {
+	naming := nid.MustNaming("book")
+	dst := make([]nid.NID, 1000)
+
+	b.ResetTimer()
+
+	for range b.N {
+		naming.NewBatch(dst)
+	}
+
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(dst)), "ns/id")
+}
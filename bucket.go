@@ -0,0 +1,151 @@
+package nid
+
+import "time"
+
+// MinBaseAt returns the smallest [Base] of the [LayoutDefault] created at the given time.
+// Together with the [MaxBaseAt] it can be used to query the identifiers created within a time range.
+// Use [Layout.MinBaseAt] for other layouts.
+func MinBaseAt(ts time.Time) Base {
+	return LayoutDefault.MinBaseAt(ts)
+}
+
+// MaxBaseAt returns the largest [Base] of the [LayoutDefault] created at the given time.
+func MaxBaseAt(ts time.Time) Base {
+	return LayoutDefault.MaxBaseAt(ts)
+}
+
+// Bucket is a half-open time range [Start, End) of the identifiers creation time.
+type Bucket struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Min returns the smallest [Base] of the [LayoutDefault] created within the [Bucket].
+// Use the [Bucket.Bounds] for other layouts.
+func (b Bucket) Min() Base {
+	minBase, _ := b.Bounds(LayoutDefault)
+
+	return minBase
+}
+
+// Max returns the largest [Base] of the [LayoutDefault] created within the [Bucket].
+// Use the [Bucket.Bounds] for other layouts.
+func (b Bucket) Max() Base {
+	_, maxBase := b.Bounds(LayoutDefault)
+
+	return maxBase
+}
+
+// Bounds returns the smallest and the largest [Base] of the [Layout] created within the [Bucket],
+// e.g. to prune the partitions of the identifiers created by the [Naming] with the given [Naming.Layout].
+func (b Bucket) Bounds(l Layout) (Base, Base) {
+	return l.MinBaseAt(l.ceil(b.Start)), l.MaxBaseAt(l.ceil(b.End).Add(-l.Precision()))
+}
+
+// Contains returns true if the [Base] of any [Layout] was created within the [Bucket].
+func (b Bucket) Contains(base Base) bool {
+	ts := base.Time()
+
+	return !ts.Before(b.Start) && ts.Before(b.End)
+}
+
+// String returns the string representation of the [Bucket].
+func (b Bucket) String() string {
+	return "[" + b.Start.Format(time.RFC3339Nano) + ", " + b.End.Format(time.RFC3339Nano) + ")"
+}
+
+type periodUnit int
+
+const (
+	periodDuration periodUnit = iota
+	periodHour
+	periodDay
+	periodWeek
+	periodMonth
+)
+
+// Period splits the time into the [Bucket]s, e.g. to route the identifiers to time-partitioned storage.
+// Calendar periods are aligned to the time zone, so a daily bucket starts at the local midnight.
+type Period struct {
+	unit periodUnit
+	d    time.Duration
+	loc  *time.Location
+}
+
+// Hourly returns the [Period] of one hour in the given time zone. A nil location means UTC.
+func Hourly(loc *time.Location) Period {
+	return Period{unit: periodHour, loc: loc}
+}
+
+// Daily returns the [Period] of one day in the given time zone. A nil location means UTC.
+func Daily(loc *time.Location) Period {
+	return Period{unit: periodDay, loc: loc}
+}
+
+// Weekly returns the [Period] of one week starting on Monday in the given time zone. A nil location means UTC.
+func Weekly(loc *time.Location) Period {
+	return Period{unit: periodWeek, loc: loc}
+}
+
+// Monthly returns the [Period] of one calendar month in the given time zone. A nil location means UTC.
+func Monthly(loc *time.Location) Period {
+	return Period{unit: periodMonth, loc: loc}
+}
+
+// Every returns the [Period] of the custom duration aligned to the Unix epoch.
+// The duration is truncated to milliseconds. It panics if the duration is less than a millisecond.
+func Every(d time.Duration) Period {
+	d = d.Truncate(time.Millisecond)
+	if d <= 0 {
+		panic("nid: period must be at least a millisecond")
+	}
+
+	return Period{unit: periodDuration, d: d}
+}
+
+// Bucket returns the [Bucket] the [Base] was created in.
+func (p Period) Bucket(base Base) Bucket {
+	return p.BucketAt(base.Time())
+}
+
+// BucketAt returns the [Bucket] containing the given time.
+func (p Period) BucketAt(ts time.Time) Bucket {
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	ts = ts.In(loc)
+	year, month, day := ts.Date()
+
+	var start, end time.Time
+
+	switch p.unit {
+	case periodHour:
+		// Truncating the elapsed time keeps the repeated hour of a DST fall-back apart.
+		start = ts.Add(-(time.Duration(ts.Minute())*time.Minute +
+			time.Duration(ts.Second())*time.Second + time.Duration(ts.Nanosecond())))
+		end = start.Add(time.Hour)
+	case periodDay:
+		start = time.Date(year, month, day, 0, 0, 0, 0, loc)
+		end = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+	case periodWeek:
+		day -= (int(ts.Weekday()) + 6) % 7
+		start = time.Date(year, month, day, 0, 0, 0, 0, loc)
+		end = time.Date(year, month, day+7, 0, 0, 0, 0, loc)
+	case periodMonth:
+		start = time.Date(year, month, 1, 0, 0, 0, 0, loc)
+		end = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+	default:
+		if p.d <= 0 {
+			panic("nid: period was not initialized")
+		}
+
+		ms, size := ts.UnixMilli(), p.d.Milliseconds()
+		ms -= ((ms % size) + size) % size
+		start = time.UnixMilli(ms).In(loc)
+		end = start.Add(p.d)
+	}
+
+	return Bucket{Start: start, End: end}
+}
@@ -0,0 +1,180 @@
+package nid_test
+
+import (
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+func TestPeriodBucket(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skipf("time zone database is not available: %v", err)
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database is not available: %v", err)
+	}
+
+	india := time.FixedZone("IST", 5*60*60+30*60)
+	ts := time.Date(2024, time.November, 6, 13, 3, 42, 207_000_000, time.UTC) // Wednesday
+
+	tt := []struct {
+		name      string
+		period    nid.Period
+		at        time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "hourly_utc",
+			period:    nid.Hourly(nil),
+			wantStart: time.Date(2024, time.November, 6, 13, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.November, 6, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "hourly_half_hour_zone",
+			period:    nid.Hourly(india),
+			wantStart: time.Date(2024, time.November, 6, 18, 0, 0, 0, india),
+			wantEnd:   time.Date(2024, time.November, 6, 19, 0, 0, 0, india),
+		},
+		{
+			name:      "hourly_repeated_hour",
+			period:    nid.Hourly(newYork),
+			at:        time.Date(2024, time.November, 3, 6, 30, 0, 0, time.UTC), // second 01:30 of the DST fall-back
+			wantStart: time.Date(2024, time.November, 3, 6, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.November, 3, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "daily_utc",
+			period:    nid.Daily(nil),
+			wantStart: time.Date(2024, time.November, 6, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.November, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "daily_zone",
+			period:    nid.Daily(kyiv),
+			wantStart: time.Date(2024, time.November, 6, 0, 0, 0, 0, kyiv),
+			wantEnd:   time.Date(2024, time.November, 7, 0, 0, 0, 0, kyiv),
+		},
+		{
+			name:      "weekly",
+			period:    nid.Weekly(nil),
+			wantStart: time.Date(2024, time.November, 4, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.November, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "monthly",
+			period:    nid.Monthly(kyiv),
+			wantStart: time.Date(2024, time.November, 1, 0, 0, 0, 0, kyiv),
+			wantEnd:   time.Date(2024, time.December, 1, 0, 0, 0, 0, kyiv),
+		},
+		{
+			name:      "every_15m",
+			period:    nid.Every(15 * time.Minute),
+			wantStart: time.Date(2024, time.November, 6, 13, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.November, 6, 13, 15, 0, 0, time.UTC),
+		},
+		{
+			name:      "every_7h",
+			period:    nid.Every(7 * time.Hour),
+			wantStart: time.Date(2024, time.November, 6, 10, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.November, 6, 17, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			at := tc.at
+			if at.IsZero() {
+				at = ts
+			}
+
+			base := nid.NewBaseAt(at)
+			got := tc.period.Bucket(base)
+
+			if !got.Start.Equal(tc.wantStart) || !got.End.Equal(tc.wantEnd) {
+				t.Errorf("Period.Bucket() = %s; want = [%s, %s)", got, tc.wantStart, tc.wantEnd)
+			}
+
+			if !got.Contains(base) || !got.Contains(got.Min()) || !got.Contains(got.Max()) {
+				t.Errorf("Bucket.Contains() = false; want = true")
+			}
+
+			if got.Contains(nid.MaxBaseAt(got.Start.Add(-time.Millisecond))) || got.Contains(nid.MinBaseAt(got.End)) {
+				t.Errorf("Bucket.Contains() = true for base outside of %s", got)
+			}
+
+			if next := tc.period.BucketAt(got.End); !next.Start.Equal(got.End) {
+				t.Errorf("Period.BucketAt(End).Start = %s; want = %s", next.Start, got.End)
+			}
+		})
+	}
+}
+
+func TestBucketBounds(t *testing.T) {
+	bucket := nid.Hourly(nil).BucketAt(time.Date(2024, time.November, 6, 13, 3, 42, 0, time.UTC))
+
+	for _, l := range []nid.Layout{nid.LayoutDefault, nid.LayoutCompact, nid.LayoutMicro, nid.LayoutNano} {
+		t.Run(l.String(), func(t *testing.T) {
+			minBase, maxBase := bucket.Bounds(l)
+
+			if minBase.Layout() != l || maxBase.Layout() != l {
+				t.Errorf("Bucket.Bounds() layouts = %v, %v; want = %v", minBase.Layout(), maxBase.Layout(), l)
+			}
+
+			if !minBase.Time().Equal(bucket.Start) || !maxBase.Time().Equal(bucket.End.Add(-l.Precision())) {
+				t.Errorf("Bucket.Bounds() times = %v, %v; want within %s", minBase.Time(), maxBase.Time(), bucket)
+			}
+
+			for _, ts := range []time.Time{bucket.Start, bucket.End.Add(-l.Precision())} {
+				if base := l.NewBaseAt(ts); nid.CompareBase(minBase, base) > 0 || nid.CompareBase(base, maxBase) > 0 {
+					t.Errorf("Layout.NewBaseAt(%v) = %s; out of [%s, %s]", ts, base, minBase, maxBase)
+				}
+			}
+
+			for _, ts := range []time.Time{bucket.Start.Add(-l.Precision()), bucket.End} {
+				if base := l.NewBaseAt(ts); nid.CompareBase(minBase, base) <= 0 && nid.CompareBase(base, maxBase) <= 0 {
+					t.Errorf("Layout.NewBaseAt(%v) = %s; within [%s, %s]", ts, base, minBase, maxBase)
+				}
+			}
+		})
+	}
+
+	if minBase, maxBase := bucket.Bounds(nid.LayoutDefault); bucket.Min() != minBase || bucket.Max() != maxBase {
+		t.Errorf("Bucket.Min/Max() = %s, %s; want = %s, %s", bucket.Min(), bucket.Max(), minBase, maxBase)
+	}
+}
+
+func TestMinMaxBaseAt(t *testing.T) {
+	ts := time.UnixMilli(1730898222207)
+
+	minBase, maxBase := nid.MinBaseAt(ts), nid.MaxBaseAt(ts)
+
+	if got, want := minBase.String(), "000034o1ibe7u0000000000000"; got != want {
+		t.Errorf("MinBaseAt() = %s; want = %s", got, want)
+	}
+
+	if got, want := maxBase.String(), "000034o1ibe7vvvvvvvvvvvvvs"; got != want {
+		t.Errorf("MaxBaseAt() = %s; want = %s", got, want)
+	}
+
+	for i := 0; i < 100; i++ {
+		base := nid.NewBaseAt(ts)
+		if nid.CompareBase(minBase, base) > 0 || nid.CompareBase(base, maxBase) > 0 {
+			t.Fatalf("NewBaseAt() = %s; out of [%s, %s]", base, minBase, maxBase)
+		}
+	}
+}
+
+func TestEvery(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Every(time.Microsecond) panic = nil; wantPanic = true")
+		}
+	}()
+
+	nid.Every(time.Microsecond)
+}
@@ -0,0 +1,167 @@
+package nid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"strings"
+)
+
+const maxKeys = len(encStr)
+
+// Key is a secret key with the identifier used for the key rotation.
+type Key struct {
+	// ID of the key in the range [0, 32). It's embedded into the encrypted text as a single character.
+	ID int
+	// Secret of the key. For the [Cipher] it must be an AES key of 16, 24 or 32 bytes.
+	Secret []byte
+}
+
+// Cipher is a keyed reversible transform of the [Base] that hides its creation time from the clients.
+// The [Base] is encrypted as a single AES block, so the same [Base] always results in the same text.
+//
+// The text form is a key ID character followed by 26 characters of the encrypted [Base].
+// The first key is used to encrypt, while all keys are used to decrypt, which allows to rotate the keys.
+type Cipher struct {
+	primary int
+	blocks  [maxKeys]cipher.Block
+}
+
+// NewCipher creates a new [Cipher] from the keys. The first key is used for encryption.
+func NewCipher(keys ...Key) (*Cipher, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: at least one key is required", ErrInvalidKey)
+	}
+
+	c := &Cipher{primary: keys[0].ID}
+
+	for _, key := range keys {
+		if key.ID < 0 || key.ID >= maxKeys {
+			return nil, fmt.Errorf("%w: key id must be in range [0, %d): %d", ErrInvalidKey, maxKeys, key.ID)
+		} else if c.blocks[key.ID] != nil {
+			return nil, fmt.Errorf("%w: duplicate key id: %d", ErrInvalidKey, key.ID)
+		}
+
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+		}
+
+		c.blocks[key.ID] = block
+	}
+
+	return c, nil
+}
+
+// MustCipher is a helper to create the [Cipher]. It panics if the keys are invalid.
+func MustCipher(keys ...Key) *Cipher {
+	c, err := NewCipher(keys...)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Encrypt the [Base] with the primary key. An empty [Base] results in an empty string.
+func (c *Cipher) Encrypt(base Base) string {
+	if base.Empty() {
+		return ""
+	}
+
+	var dst Base
+
+	c.blocks[c.primary].Encrypt(dst[:], base[:])
+
+	return string(encStr[c.primary]) + dst.String()
+}
+
+// Decrypt the [Base] from the text using the key embedded into it.
+func (c *Cipher) Decrypt(str string) (Base, error) {
+	if len(str) == 0 {
+		return Base{}, nil
+	} else if len(str) != 1+encoding.EncodedLen(baseLen) {
+		return Base{}, fmt.Errorf("%w: invalid encrypted base id length: %d", ErrFailedParse, len(str))
+	}
+
+	id := strings.IndexByte(encStr, str[0])
+	if id < 0 || c.blocks[id] == nil {
+		return Base{}, fmt.Errorf("%w: unknown key id: %q", ErrFailedParse, str[0])
+	}
+
+	src, err := ParseBase(str[1:])
+	if err != nil {
+		return Base{}, err
+	}
+
+	var dst Base
+
+	c.blocks[id].Decrypt(dst[:], src[:])
+
+	return dst, nil
+}
+
+// WithCipher sets the [Cipher] used by the [Naming.External] and the [Naming.ParseExternal].
+func WithCipher(c *Cipher) Option {
+	return func(cfg *config) error {
+		if c == nil {
+			return fmt.Errorf("%w: cipher must not be nil", ErrInvalidOption)
+		}
+
+		cfg.cipher = c
+
+		return nil
+	}
+}
+
+// External returns the public text representation of the [NID].
+// If the [Naming] has a [Cipher], the base is encrypted, so the representation doesn't reveal the creation time.
+// Otherwise, it's the same as the [NID.String].
+func (n Naming) External(id NID) string {
+	n.initialized()
+
+	c := n.cipher()
+	if c == nil || id.Empty() {
+		return id.String()
+	}
+
+	prefix := id.name
+	if id.namespace != "" {
+		prefix = id.namespace + "." + prefix
+	}
+
+	return prefix + string(id.separator()) + c.Encrypt(id.base)
+}
+
+// ParseExternal parses the [NID] from the public text representation created by the [Naming.External].
+func (n Naming) ParseExternal(str string) (NID, error) {
+	n.initialized()
+
+	c := n.cipher()
+	if c == nil || len(str) == 0 {
+		return n.Parse(str)
+	}
+
+	namespace, name, text, err := n.split(str, 1+encoding.EncodedLen(baseLen))
+	if err != nil {
+		return NID{}, err
+	}
+
+	base, err := c.Decrypt(text)
+	if err != nil {
+		return NID{}, err
+	} else if base.Empty() {
+		return NID{}, nil
+	}
+
+	return n.canonicalize(NID{namespace: namespace, name: name, sep: n.sep(), base: base}), nil
+}
+
+// cipher returns the [Cipher] of the [Naming] or nil.
+func (n Naming) cipher() *Cipher {
+	if n.cfg == nil {
+		return nil
+	}
+
+	return n.cfg.cipher
+}
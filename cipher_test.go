@@ -0,0 +1,173 @@
+package nid_test
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNewCipher(t *testing.T) {
+	secret := bytes.Repeat([]byte{1}, 16)
+
+	tt := []struct {
+		name    string
+		keys    []nid.Key
+		wantErr bool
+	}{
+		{
+			name: "single",
+			keys: []nid.Key{{ID: 0, Secret: secret}},
+		},
+		{
+			name: "rotation",
+			keys: []nid.Key{{ID: 2, Secret: bytes.Repeat([]byte{2}, 32)}, {ID: 1, Secret: secret}},
+		},
+		{
+			name:    "no_keys",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_id",
+			keys:    []nid.Key{{ID: 32, Secret: secret}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate_id",
+			keys:    []nid.Key{{ID: 1, Secret: secret}, {ID: 1, Secret: secret}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_secret",
+			keys:    []nid.Key{{ID: 1, Secret: []byte("short")}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nid.NewCipher(tc.keys...)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("NewCipher() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr && !errors.Is(err, nid.ErrInvalidKey) {
+				t.Errorf("NewCipher() err = %v; want = %v", err, nid.ErrInvalidKey)
+			}
+		})
+	}
+}
+
+func TestNaming_External(t *testing.T) {
+	oldKey := nid.Key{ID: 1, Secret: bytes.Repeat([]byte{1}, 16)}
+	newKey := nid.Key{ID: 2, Secret: bytes.Repeat([]byte{2}, 16)}
+
+	plainIDN := nid.MustNaming("book")
+	oldIDN := nid.MustNaming("book", nid.WithCipher(nid.MustCipher(oldKey)))
+	newIDN := nid.MustNaming("book", nid.WithCipher(nid.MustCipher(newKey, oldKey)))
+
+	id := nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+
+	if got := plainIDN.External(id); got != id.String() {
+		t.Errorf("Naming.External() without cipher = %s; want = %s", got, id)
+	}
+
+	oldExt := oldIDN.External(id)
+	newExt := newIDN.External(id)
+
+	for _, ext := range []string{oldExt, newExt} {
+		if !strings.HasPrefix(ext, "book_") || strings.Contains(ext, id.Base().String()) {
+			t.Errorf("Naming.External() = %s; want opaque book identifier", ext)
+		}
+
+		if !regexp.MustCompile(newIDN.JSONSchema().Pattern).MatchString(ext) {
+			t.Errorf("Naming.External() = %s; doesn't match schema %s", ext, newIDN.JSONSchema().Pattern)
+		}
+
+		got, err := newIDN.ParseExternal(ext)
+		if err != nil {
+			t.Fatalf("Naming.ParseExternal(%s) unexpected err = %v", ext, err)
+		}
+
+		if got != id {
+			t.Errorf("Naming.ParseExternal(%s) = %s; want = %s", ext, got, id)
+		}
+	}
+
+	if oldExt == newExt {
+		t.Errorf("Naming.External() = %s; want different text after key rotation", newExt)
+	}
+
+	if _, err := oldIDN.ParseExternal(newExt); !errors.Is(err, nid.ErrFailedParse) {
+		t.Errorf("Naming.ParseExternal() with unknown key err = %v; want = %v", err, nid.ErrFailedParse)
+	}
+
+	scoped := mustScope(newIDN, "acme").New()
+	if ext := newIDN.External(scoped); !strings.HasPrefix(ext, "acme.book_") {
+		t.Errorf("Naming.External() = %s; want prefix acme.book_", ext)
+	} else if got, err := mustScope(newIDN, "acme").ParseExternal(ext); err != nil || got != scoped {
+		t.Errorf("Naming.ParseExternal(%s) = %s, %v; want = %s", ext, got, err, scoped)
+	} else if _, err := mustScope(newIDN, "other").ParseExternal(ext); !errors.Is(err, nid.ErrFailedParse) {
+		t.Errorf("Naming.ParseExternal(%s) other namespace err = %v; want = %v", ext, err, nid.ErrFailedParse)
+	}
+
+	a, b := newIDN.New(), newIDN.New()
+	if newIDN.External(a) == newIDN.External(b) {
+		t.Errorf("Naming.External() is the same for different identifiers")
+	}
+}
+
+func TestNaming_ParseExternal(t *testing.T) {
+	idn := nid.MustNaming("book", nid.WithCipher(nid.MustCipher(nid.Key{ID: 0, Secret: bytes.Repeat([]byte{1}, 16)})))
+
+	tt := []struct {
+		name    string
+		str     string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			str:  "",
+		},
+		{
+			name:    "plain",
+			str:     "book_000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "other_name",
+			str:     "author_0000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "no_name",
+			str:     "0000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_base",
+			str:     "book_0!00034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := idn.ParseExternal(tc.str)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("Naming.ParseExternal() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr && !errors.Is(err, nid.ErrFailedParse) {
+				t.Errorf("Naming.ParseExternal() err = %v; want = %v", err, nid.ErrFailedParse)
+			}
+
+			if !got.Empty() {
+				t.Errorf("Naming.ParseExternal() = %s; want empty", got)
+			}
+		})
+	}
+}
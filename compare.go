@@ -1,13 +1,26 @@
 package nid
 
 // Compare two [NID] identifiers.
+// The identifiers are ordered by the namespace, the name and then the [Base].
 func Compare(a, b NID) int {
+	if a.namespace < b.namespace {
+		return -1
+	} else if a.namespace > b.namespace {
+		return 1
+	}
+
 	if a.name < b.name {
 		return -1
 	} else if a.name > b.name {
 		return 1
 	}
 
+	if a.sep < b.sep {
+		return -1
+	} else if a.sep > b.sep {
+		return 1
+	}
+
 	return CompareBase(a.base, b.base)
 }
 
@@ -131,6 +131,22 @@ func TestCompare(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "less by namespace",
+			args: args{
+				a: mustScope(nid.MustNaming("example_b"), "acme").Apply(nid.Base{2}),
+				b: mustScope(nid.MustNaming("example_a"), "other").Apply(nid.Base{1}),
+			},
+			want: -1,
+		},
+		{
+			name: "greater by namespace",
+			args: args{
+				a: mustScope(nid.MustNaming("example"), "acme").Apply(nid.Base{1}),
+				b: nid.MustNaming("example").Apply(nid.Base{1}),
+			},
+			want: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,26 @@
+package nid
+
+import "context"
+
+type contextKey string
+
+// NewContext returns a copy of the context with the [NID], e.g. the request ID.
+// Every [Naming] has its own key, so the [NID]s of different names are stored side by side.
+func (n Naming) NewContext(ctx context.Context, id NID) context.Context {
+	n.initialized()
+
+	return context.WithValue(ctx, contextKey(n.name), id)
+}
+
+// FromContext returns the [NID] of the [Naming] stored by the [Naming.NewContext].
+// It returns false if the context has no [NID] of the [Naming] or it doesn't match, see [Naming.Is].
+func (n Naming) FromContext(ctx context.Context) (NID, bool) {
+	n.initialized()
+
+	id, ok := ctx.Value(contextKey(n.name)).(NID)
+	if !ok || !n.Is(id) {
+		return NID{}, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,63 @@
+package nid_test
+
+import (
+	"context"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNaming_NewContext(t *testing.T) {
+	var (
+		request = nid.MustNaming("request")
+		trace   = nid.MustNaming("trace")
+		acme    = mustScope(request, "acme")
+	)
+
+	requestID, traceID := request.New(), trace.New()
+
+	ctx := request.NewContext(context.Background(), requestID)
+	ctx = trace.NewContext(ctx, traceID)
+
+	tt := []struct {
+		name   string
+		naming nid.Naming
+		ctx    context.Context
+		want   nid.NID
+		wantOK bool
+	}{
+		{
+			name:   "request",
+			naming: request,
+			ctx:    ctx,
+			want:   requestID,
+			wantOK: true,
+		},
+		{
+			name:   "trace",
+			naming: trace,
+			ctx:    ctx,
+			want:   traceID,
+			wantOK: true,
+		},
+		{
+			name:   "other_namespace",
+			naming: acme,
+			ctx:    ctx,
+		},
+		{
+			name:   "empty",
+			naming: request,
+			ctx:    context.Background(),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.naming.FromContext(tc.ctx)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Naming.FromContext() = %v, %v; want = %v, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
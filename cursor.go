@@ -0,0 +1,205 @@
+package nid
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+)
+
+const (
+	cursorVersion  = 1
+	cursorBackward = 1 << 0
+	cursorNamed    = 1 << 1
+)
+
+// Direction of the page relative to the [Cursor].
+type Direction int
+
+const (
+	// Forward pages contain the identifiers after the [Cursor] in ascending order.
+	Forward Direction = iota
+	// Backward pages contain the identifiers before the [Cursor] in descending order.
+	Backward
+)
+
+// String returns the name of the [Direction].
+func (d Direction) String() string {
+	if d == Backward {
+		return "backward"
+	}
+
+	return "forward"
+}
+
+// Cursor is a pagination token pointing at the [NID] or the [Base] of the last seen item with the page [Direction].
+// It's encoded as an opaque URL-safe string, see [Cursor.String]. The zero value is the cursor of the first page.
+type Cursor struct {
+	naming    Naming
+	id        NID
+	base      Base
+	direction Direction
+}
+
+// After returns the [Cursor] of the page after the [NID], e.g. the last item of the current page.
+// The [NID] is converted with the [Naming.Update], so the [Cursor] always matches the [Naming].
+func (n Naming) After(id NID) Cursor {
+	id = n.Update(id)
+
+	return Cursor{naming: n, id: id, base: id.base, direction: Forward}
+}
+
+// Before returns the [Cursor] of the page before the [NID], e.g. the first item of the current page.
+// The [NID] is converted with the [Naming.Update], so the [Cursor] always matches the [Naming].
+func (n Naming) Before(id NID) Cursor {
+	id = n.Update(id)
+
+	return Cursor{naming: n, id: id, base: id.base, direction: Backward}
+}
+
+// AfterBase returns the [Cursor] of the page after the [Base].
+func AfterBase(base Base) Cursor {
+	return Cursor{base: base, direction: Forward}
+}
+
+// BeforeBase returns the [Cursor] of the page before the [Base].
+func BeforeBase(base Base) Cursor {
+	return Cursor{base: base, direction: Backward}
+}
+
+// ParseCursor parses the [Base] [Cursor] from the string. An empty string results in the first page [Cursor].
+// Use the [Naming.ParseCursor] to parse the [NID] cursors.
+func ParseCursor(str string) (Cursor, error) {
+	flags, payload, err := decodeCursor(str)
+	if err != nil || str == "" {
+		return Cursor{}, err
+	} else if flags&cursorNamed != 0 {
+		return Cursor{}, fmt.Errorf("%w: cursor of a named identifier", ErrFailedParse)
+	} else if len(payload) != baseLen {
+		return Cursor{}, fmt.Errorf("%w: invalid cursor base length: %d", ErrFailedParse, len(payload))
+	}
+
+	return Cursor{base: Base(payload), direction: flags.direction()}, nil
+}
+
+// ParseCursor parses the [NID] [Cursor] from the string and checks that the [NID] matches the [Naming].
+// An empty string results in the first page [Cursor].
+func (n Naming) ParseCursor(str string) (Cursor, error) {
+	n.initialized()
+
+	flags, payload, err := decodeCursor(str)
+	if err != nil {
+		return Cursor{}, err
+	} else if str == "" {
+		return Cursor{naming: n}, nil
+	} else if flags&cursorNamed == 0 {
+		return Cursor{}, fmt.Errorf("%w: cursor of a base identifier", ErrFailedParse)
+	}
+
+	id, err := n.Parse(string(payload))
+	if err != nil {
+		return Cursor{}, err
+	} else if id.Empty() {
+		return Cursor{}, fmt.Errorf("%w: cursor of an empty identifier", ErrFailedParse)
+	}
+
+	return Cursor{naming: n, id: id, base: id.base, direction: flags.direction()}, nil
+}
+
+// ID returns the [NID] of the [Cursor] or an empty [NID] for the [Base] cursors.
+func (c Cursor) ID() NID {
+	return c.id
+}
+
+// Base returns the [Base] of the [Cursor].
+func (c Cursor) Base() Base {
+	return c.base
+}
+
+// Direction returns the [Direction] of the page.
+func (c Cursor) Direction() Direction {
+	return c.direction
+}
+
+// Empty returns true if the [Cursor] points at the first page.
+func (c Cursor) Empty() bool {
+	return c.base.Empty()
+}
+
+// SQL returns the condition and the order of the page query for the column with the placeholder of the [Cursor.Value],
+// e.g. "id > $1" and "id ASC" for the [Forward] page. The condition is empty for the first page.
+// The rows of the [Backward] page are in descending order, so they should be reversed before rendering.
+func (c Cursor) SQL(column, placeholder string) (string, string) {
+	op, order := ">", "ASC"
+	if c.direction == Backward {
+		op, order = "<", "DESC"
+	}
+
+	if c.Empty() {
+		return "", column + " " + order
+	}
+
+	return column + " " + op + " " + placeholder, column + " " + order
+}
+
+// Value returns the driver value of the [Cursor] bound. The [NID] cursors use the [Naming] storage format.
+func (c Cursor) Value() (driver.Value, error) {
+	if c.id.Empty() {
+		return c.base.Value()
+	}
+
+	return c.naming.Column(&c.id).Value()
+}
+
+// String returns the opaque URL-safe representation of the [Cursor].
+// The first page [Cursor] results in an empty string.
+func (c Cursor) String() string {
+	if c.Empty() {
+		return ""
+	}
+
+	var flags cursorFlags
+	if c.direction == Backward {
+		flags |= cursorBackward
+	}
+
+	payload := c.base.Bytes()
+	if !c.id.Empty() {
+		flags |= cursorNamed
+		payload = []byte(c.id.String())
+	}
+
+	return base64.RawURLEncoding.EncodeToString(append([]byte{cursorVersion, byte(flags)}, payload...))
+}
+
+// MarshalText returns the text representation of the [Cursor].
+func (c Cursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+type cursorFlags byte
+
+func (f cursorFlags) direction() Direction {
+	if f&cursorBackward != 0 {
+		return Backward
+	}
+
+	return Forward
+}
+
+// decodeCursor decodes the version, the flags and the payload of the [Cursor].
+func decodeCursor(str string) (cursorFlags, []byte, error) {
+	if str == "" {
+		return 0, nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: invalid cursor encoding: %w", ErrFailedParse, err)
+	} else if len(data) < 2 || data[0] != cursorVersion {
+		return 0, nil, fmt.Errorf("%w: unsupported cursor version", ErrFailedParse)
+	} else if flags := cursorFlags(data[1]); flags&^(cursorBackward|cursorNamed) != 0 {
+		return 0, nil, fmt.Errorf("%w: invalid cursor flags: %#x", ErrFailedParse, data[1])
+	}
+
+	return cursorFlags(data[1]), data[2:], nil
+}
@@ -0,0 +1,210 @@
+package nid_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestCursor(t *testing.T) {
+	var (
+		naming = nid.MustNaming("book")
+		id     = nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+		base   = id.Base()
+	)
+
+	scoped, err := naming.Scope("acme")
+	if err != nil {
+		t.Fatalf("Naming.Scope() unexpected err = %v", err)
+	}
+
+	tt := []struct {
+		name      string
+		cursor    nid.Cursor
+		parse     func(string) (nid.Cursor, error)
+		wantWhere string
+		wantOrder string
+		wantValue any
+	}{
+		{
+			name:      "after_id",
+			cursor:    naming.After(id),
+			parse:     naming.ParseCursor,
+			wantWhere: "id > $1",
+			wantOrder: "id ASC",
+			wantValue: "book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:      "before_id",
+			cursor:    naming.Before(id),
+			parse:     naming.ParseCursor,
+			wantWhere: "id < $1",
+			wantOrder: "id DESC",
+			wantValue: "book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:      "after_other_name",
+			cursor:    naming.After(nid.MustParse("author_000034o1ibe7u02570ak9evj9s")),
+			parse:     naming.ParseCursor,
+			wantWhere: "id > $1",
+			wantOrder: "id ASC",
+			wantValue: "book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:      "before_scoped",
+			cursor:    scoped.Before(id),
+			parse:     scoped.ParseCursor,
+			wantWhere: "id < $1",
+			wantOrder: "id DESC",
+			wantValue: "acme.book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:      "after_id_base_storage",
+			cursor:    nid.MustNaming("book", nid.WithStorage(nid.StorageBase)).After(id),
+			parse:     nid.MustNaming("book", nid.WithStorage(nid.StorageBase)).ParseCursor,
+			wantWhere: "id > $1",
+			wantOrder: "id ASC",
+			wantValue: "000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:      "after_base",
+			cursor:    nid.AfterBase(base),
+			parse:     nid.ParseCursor,
+			wantWhere: "id > $1",
+			wantOrder: "id ASC",
+			wantValue: base.Bytes(),
+		},
+		{
+			name:      "before_base",
+			cursor:    nid.BeforeBase(base),
+			parse:     nid.ParseCursor,
+			wantWhere: "id < $1",
+			wantOrder: "id DESC",
+			wantValue: base.Bytes(),
+		},
+		{
+			name:      "first_page",
+			cursor:    nid.Cursor{},
+			parse:     nid.ParseCursor,
+			wantOrder: "id ASC",
+		},
+	}
+
+	urlSafe := regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			str := tc.cursor.String()
+			if !urlSafe.MatchString(str) {
+				t.Errorf("Cursor.String() = %q; want URL-safe", str)
+			}
+
+			got, err := tc.parse(str)
+			if err != nil {
+				t.Fatalf("ParseCursor() unexpected err = %v", err)
+			}
+
+			if got.ID() != tc.cursor.ID() || got.Base() != tc.cursor.Base() || got.Direction() != tc.cursor.Direction() {
+				t.Errorf("ParseCursor() = %v %v %v; want = %v %v %v",
+					got.ID(), got.Base(), got.Direction(), tc.cursor.ID(), tc.cursor.Base(), tc.cursor.Direction())
+			}
+
+			where, order := got.SQL("id", "$1")
+			if where != tc.wantWhere || order != tc.wantOrder {
+				t.Errorf("Cursor.SQL() = %q, %q; want = %q, %q", where, order, tc.wantWhere, tc.wantOrder)
+			}
+
+			value, err := got.Value()
+			if err != nil {
+				t.Fatalf("Cursor.Value() unexpected err = %v", err)
+			}
+
+			if b, ok := tc.wantValue.([]byte); ok {
+				if v, _ := value.([]byte); string(v) != string(b) {
+					t.Errorf("Cursor.Value() = %v; want = %v", value, tc.wantValue)
+				}
+			} else if value != tc.wantValue {
+				t.Errorf("Cursor.Value() = %v; want = %v", value, tc.wantValue)
+			}
+		})
+	}
+}
+
+func TestCursorNamingNotInitialized(t *testing.T) {
+	for name, cursor := range map[string]func(nid.NID) nid.Cursor{
+		"after":  nid.Naming{}.After,
+		"before": nid.Naming{}.Before,
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Naming{}.%s() panic = nil; want panic", name)
+				}
+			}()
+
+			_ = cursor(nid.MustParse("book_000034o1ibe7u02570ak9evj9s"))
+		})
+	}
+}
+
+func TestParseCursorErrors(t *testing.T) {
+	var (
+		book   = nid.MustNaming("book")
+		id     = book.New()
+		named  = book.After(id).String()
+		based  = nid.AfterBase(id.Base()).String()
+		author = nid.MustNaming("author")
+	)
+
+	tt := []struct {
+		name  string
+		str   string
+		parse func(string) (nid.Cursor, error)
+	}{
+		{
+			name:  "invalid_encoding",
+			str:   "!!!",
+			parse: nid.ParseCursor,
+		},
+		{
+			name:  "invalid_version",
+			str:   "AgA",
+			parse: nid.ParseCursor,
+		},
+		{
+			name:  "invalid_flags",
+			str:   "AQQ",
+			parse: nid.ParseCursor,
+		},
+		{
+			name:  "named_as_base",
+			str:   named,
+			parse: nid.ParseCursor,
+		},
+		{
+			name:  "base_as_named",
+			str:   based,
+			parse: book.ParseCursor,
+		},
+		{
+			name:  "other_naming",
+			str:   named,
+			parse: author.ParseCursor,
+		},
+		{
+			name:  "truncated",
+			str:   based[:len(based)-2],
+			parse: nid.ParseCursor,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.parse(tc.str); !errors.Is(err, nid.ErrFailedParse) {
+				t.Errorf("ParseCursor(%q) err = %v; want = %v", tc.str, err, nid.ErrFailedParse)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package nid
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+)
+
+// entropyChunk is the size of the buffer refilled from the [rand.Reader] at once.
+const entropyChunk = 4096
+
+//nolint:gochecknoglobals
+var entropyPool = sync.Pool{
+	New: func() any {
+		return &entropyBuffer{pos: entropyChunk}
+	},
+}
+
+// entropyBuffer is a chunk of the random bytes consumed from the start to the end.
+// The [sync.Pool] keeps the buffers per P, so the concurrent readers rarely contend.
+type entropyBuffer struct {
+	buf [entropyChunk]byte
+	pos int
+}
+
+// readEntropy fills the slice with the random bytes served from the pooled buffers.
+// It panics if the [rand.Reader] fails. The served bytes are cleared from the buffer,
+// so they are never handed out twice.
+func readEntropy(dst []byte) {
+	b, _ := entropyPool.Get().(*entropyBuffer)
+	defer entropyPool.Put(b)
+
+	for len(dst) > 0 {
+		if b.pos == entropyChunk {
+			if _, err := io.ReadFull(rand.Reader, b.buf[:]); err != nil {
+				panic(err)
+			}
+
+			b.pos = 0
+		}
+
+		used := b.buf[b.pos:min(b.pos+len(dst), entropyChunk)]
+		n := copy(dst, used)
+		clear(used)
+
+		b.pos += n
+		dst = dst[n:]
+	}
+}
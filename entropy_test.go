@@ -0,0 +1,103 @@
+package nid_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNewBaseConcurrent(t *testing.T) {
+	const (
+		workers = 8
+		count   = 10_000
+	)
+
+	results := make([][]nid.Base, workers)
+
+	var wg sync.WaitGroup
+
+	for w := range results {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[w] = make([]nid.Base, count)
+			for i := range results[w] {
+				results[w][i] = nid.NewBase()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	seen := make(map[nid.Base]struct{}, workers*count)
+
+	for _, bases := range results {
+		for _, base := range bases {
+			if _, ok := seen[base]; ok {
+				t.Fatalf("NewBase() = %s twice; want unique", base)
+			}
+
+			seen[base] = struct{}{}
+		}
+	}
+}
+
+func TestReadEntropy(t *testing.T) {
+	for _, n := range []int{1, 10, 4095, 4097, 3*4096 + 5} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			dst := make([]byte, n)
+			nid.ReadEntropy(dst)
+
+			if tail := dst[max(0, n-16):]; bytes.Count(tail, []byte{0}) == len(tail) && len(tail) > 8 {
+				t.Errorf("ReadEntropy() tail = %x; want random bytes", tail)
+			}
+		})
+	}
+}
+
+func BenchmarkNewBase(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = nid.NewBase()
+		}
+	})
+}
+
+func BenchmarkReadEntropy(b *testing.B) {
+	tt := []struct {
+		name string
+		read func([]byte)
+	}{
+		{
+			name: "pooled",
+			read: nid.ReadEntropy,
+		},
+		{
+			name: "direct",
+			read: func(dst []byte) {
+				if _, err := io.ReadFull(rand.Reader, dst); err != nil {
+					panic(err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		b.Run(tc.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				var buf [10]byte
+
+				for pb.Next() {
+					tc.read(buf[:])
+				}
+			})
+		})
+	}
+}
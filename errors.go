@@ -3,6 +3,12 @@ package nid
 import "fmt"
 
 var (
-	ErrFailedParse = fmt.Errorf("nid: failed to parse")
-	ErrInvalidName = fmt.Errorf("nid: invalid name")
+	ErrFailedParse       = fmt.Errorf("nid: failed to parse")
+	ErrInvalidName       = fmt.Errorf("nid: invalid name")
+	ErrInvalidOption     = fmt.Errorf("nid: invalid option")
+	ErrInvalidKey        = fmt.Errorf("nid: invalid key")
+	ErrInvalidSignature  = fmt.Errorf("nid: invalid signature")
+	ErrSequenceExhausted = fmt.Errorf("nid: sequence exhausted")
+	ErrClockRegression   = fmt.Errorf("nid: clock regression")
+	ErrUnsorted          = fmt.Errorf("nid: unsorted identifiers")
 )
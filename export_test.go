@@ -0,0 +1,11 @@
+package nid
+
+import "sync"
+
+// ReadEntropy exposes the pooled entropy reads to the benchmarks.
+var ReadEntropy = readEntropy //nolint:gochecknoglobals
+
+// ResetEntropy drops the pooled buffers, so the next generation reads the [rand.Reader].
+func ResetEntropy() {
+	entropyPool = sync.Pool{New: entropyPool.New}
+}
@@ -0,0 +1,40 @@
+package nid
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+// Var is an [expvar.Var] holding the [NID], e.g. the last processed identifier.
+// It's safe for concurrent use. The zero value holds an empty [NID].
+//
+//	var lastBookID nid.Var
+//
+//	expvar.Publish("last_book_id", &lastBookID)
+type Var struct {
+	id atomic.Pointer[NID]
+}
+
+// Set the [NID] of the [Var].
+func (v *Var) Set(id NID) {
+	v.id.Store(&id)
+}
+
+// Value returns the [NID] of the [Var].
+func (v *Var) Value() NID {
+	if id := v.id.Load(); id != nil {
+		return *id
+	}
+
+	return NID{}
+}
+
+// String returns the JSON representation of the [NID] as required by the [expvar.Var].
+func (v *Var) String() string {
+	id := v.Value()
+	if id.Empty() {
+		return "null"
+	}
+
+	return strconv.Quote(id.String())
+}
@@ -0,0 +1,26 @@
+package nid_test
+
+import (
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestVar(t *testing.T) {
+	var v nid.Var
+
+	if got := v.String(); got != "null" {
+		t.Errorf("Var.String() = %s; want = null", got)
+	}
+
+	id := nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+	v.Set(id)
+
+	if got := v.Value(); got != id {
+		t.Errorf("Var.Value() = %s; want = %s", got, id)
+	}
+
+	if got, want := v.String(), "\"book_000034o1ibe7u02570ak9evj9s\""; got != want {
+		t.Errorf("Var.String() = %s; want = %s", got, want)
+	}
+}
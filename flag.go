@@ -0,0 +1,98 @@
+package nid
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Set parses the [NID] from the string.
+// Together with the [NID.String] it implements the [flag.Value] interface, so the [NID] can be used with [flag.Var].
+func (id *NID) Set(str string) error {
+	return id.UnmarshalText([]byte(str))
+}
+
+// Set parses the [Base] from the string.
+// Together with the [Base.String] it implements the [flag.Value] interface, so the [Base] can be used with [flag.Var].
+func (base *Base) Set(str string) error {
+	return base.UnmarshalText([]byte(str))
+}
+
+// Flag returns a [flag.Value] that parses the [NID] into id and checks that it matches the [Naming].
+//
+//	flag.Var(BookIDN.Flag(&bookID), "book", "book identifier")
+func (n Naming) Flag(id *NID) flag.Value {
+	n.initialized()
+
+	return namingFlag{naming: n, id: id}
+}
+
+// ParseFunc returns a function that parses the [NID] matching the [Naming] and passes it to fn.
+// It's designed to be used with [flag.Func], e.g. to collect repeated flags.
+//
+//	flag.Func("book", "book identifier", BookIDN.ParseFunc(func(id nid.NID) error {
+//		bookIDs = append(bookIDs, id)
+//		return nil
+//	}))
+func (n Naming) ParseFunc(fn func(NID) error) func(string) error {
+	n.initialized()
+
+	return func(str string) error {
+		id, err := n.Parse(str)
+		if err != nil {
+			return err
+		}
+
+		return fn(id)
+	}
+}
+
+// LookupEnv parses the [NID] matching the [Naming] from the environment variable named by the key.
+// It reports false if the variable is not present.
+func (n Naming) LookupEnv(key string) (NID, bool, error) {
+	n.initialized()
+
+	str, ok := os.LookupEnv(key)
+	if !ok {
+		return NID{}, false, nil
+	}
+
+	id, err := n.Parse(str)
+	if err != nil {
+		return NID{}, true, fmt.Errorf("environment variable %s: %w", key, err)
+	}
+
+	return id, true, nil
+}
+
+// namingFlag is a [flag.Value] of the [NID] restricted to the [Naming].
+type namingFlag struct {
+	naming Naming
+	id     *NID
+}
+
+// String returns the string representation of the [NID].
+func (f namingFlag) String() string {
+	if f.id == nil {
+		return ""
+	}
+
+	return f.id.String()
+}
+
+// Set parses the [NID] from the string.
+func (f namingFlag) Set(str string) error {
+	id, err := f.naming.Parse(str)
+	if err != nil {
+		return err
+	}
+
+	*f.id = id
+
+	return nil
+}
+
+// Get returns the [NID] as required by the [flag.Getter].
+func (f namingFlag) Get() any {
+	return *f.id
+}
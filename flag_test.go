@@ -0,0 +1,150 @@
+package nid_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestFlag(t *testing.T) {
+	tt := []struct {
+		name     string
+		args     []string
+		wantID   nid.NID
+		wantBase nid.Base
+		wantBook nid.NID
+		wantErr  bool
+	}{
+		{
+			name: "empty",
+			args: []string{},
+		},
+		{
+			name: "valid",
+			args: []string{
+				"-id", "author_000034o1ibe7u02570ak9evj9s",
+				"-base", "000034o1ibe7u02570ak9evj9s",
+				"-book", "book_000034o1ibe7u02570ak9evj9s",
+			},
+			wantID:   nid.MustParse("author_000034o1ibe7u02570ak9evj9s"),
+			wantBase: nid.MustParseBase("000034o1ibe7u02570ak9evj9s"),
+			wantBook: nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+		},
+		{
+			name:    "invalid_id",
+			args:    []string{"-id", "author_0034o1ibe7u02570ak9evj9s"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_base",
+			args:    []string{"-base", "book_000034o1ibe7u02570ak9evj9s"},
+			wantErr: true,
+		},
+		{
+			name:    "other_naming",
+			args:    []string{"-book", "author_000034o1ibe7u02570ak9evj9s"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				id   nid.NID
+				base nid.Base
+				book nid.NID
+			)
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&id, "id", "any identifier")
+			fs.Var(&base, "base", "base identifier")
+			fs.Var(nid.MustNaming("book").Flag(&book), "book", "book identifier")
+
+			err := fs.Parse(tc.args)
+			if tc.wantErr == (err == nil) {
+				t.Fatalf("FlagSet.Parse() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if id != tc.wantID || base != tc.wantBase || book != tc.wantBook {
+				t.Errorf("FlagSet.Parse() = %s, %s, %s; want = %s, %s, %s", id, base, book, tc.wantID, tc.wantBase, tc.wantBook)
+			}
+		})
+	}
+}
+
+func TestNaming_ParseFunc(t *testing.T) {
+	var books []nid.NID
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Func("book", "book identifier", nid.MustNaming("book").ParseFunc(func(id nid.NID) error {
+		books = append(books, id)
+
+		return nil
+	}))
+
+	err := fs.Parse([]string{"-book", "book_000034o1ibe7u02570ak9evj9s", "-book", "book_000034o1ibe7u02570ak9evj9t"})
+	if err != nil {
+		t.Fatalf("FlagSet.Parse() unexpected err = %v", err)
+	}
+
+	if len(books) != 2 {
+		t.Fatalf("Naming.ParseFunc() parsed %d identifiers; want = 2", len(books))
+	}
+
+	err = nid.MustNaming("book").ParseFunc(nil)("author_000034o1ibe7u02570ak9evj9s")
+	if !errors.Is(err, nid.ErrFailedParse) {
+		t.Errorf("Naming.ParseFunc() err = %v; want = %v", err, nid.ErrFailedParse)
+	}
+}
+
+func TestNaming_LookupEnv(t *testing.T) {
+	tt := []struct {
+		name    string
+		value   *string
+		want    nid.NID
+		wantOK  bool
+		wantErr bool
+	}{
+		{
+			name: "missing",
+		},
+		{
+			name:   "valid",
+			value:  ptr("book_000034o1ibe7u02570ak9evj9s"),
+			want:   nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+			wantOK: true,
+		},
+		{
+			name:    "other_naming",
+			value:   ptr("author_000034o1ibe7u02570ak9evj9s"),
+			wantOK:  true,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.value != nil {
+				t.Setenv("NID_TEST_BOOK_ID", *tc.value)
+			}
+
+			got, ok, err := nid.MustNaming("book").LookupEnv("NID_TEST_BOOK_ID")
+			if tc.wantErr == (err == nil) {
+				t.Errorf("Naming.LookupEnv() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Naming.LookupEnv() = %s, %v; want = %s, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,145 @@
+package nid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// Format implements the [fmt.Formatter] interface.
+//
+// The following verbs are supported:
+//
+//	%s, %v  the full identifier, e.g. "book_000034o1ibe7u02570ak9evj9s"
+//	%q      the quoted full identifier
+//	%b      the base identifier only, e.g. "000034o1ibe7u02570ak9evj9s"
+//	%x, %X  the hex encoded base identifier
+//	%+v     the full identifier with the creation time
+//	%#v     the Go syntax representation, see [NID.GoString]
+func (id NID) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			writeFormat(f, id.GoString())
+		case f.Flag('+'):
+			writeFormat(f, verbose(id.String(), id.base))
+		default:
+			writeFormat(f, id.String())
+		}
+	case 's':
+		writeFormat(f, id.String())
+	case 'q':
+		writeFormat(f, strconv.Quote(id.String()))
+	case 'b':
+		writeFormat(f, id.base.String())
+	case 'x', 'X':
+		id.base.Format(f, verb)
+	default:
+		fmt.Fprintf(f, "%%!%c(nid.NID=%s)", verb, id.String())
+	}
+}
+
+// GoString returns the Go syntax representation of the [NID].
+// The [NID]s that can't be parsed with the [MustParse] are represented as an empty literal with the text in a comment.
+func (id NID) GoString() string {
+	if id.Empty() {
+		return "nid.NID{}"
+	} else if id.parsable() != nil {
+		return "nid.NID{ /* " + strconv.Quote(id.String()) + " */ }"
+	}
+
+	return "nid.MustParse(" + strconv.Quote(id.String()) + ")"
+}
+
+// LogValue implements the [slog.LogValuer] interface.
+// The [NID] is logged as a group of its namespace, name, base and creation time.
+func (id NID) LogValue() slog.Value {
+	if id.Empty() {
+		return slog.StringValue("")
+	}
+
+	attrs := make([]slog.Attr, 0, 4)
+	if id.namespace != "" {
+		attrs = append(attrs, slog.String("namespace", id.namespace))
+	}
+
+	attrs = append(attrs,
+		slog.String("name", id.name),
+		slog.String("base", id.base.String()),
+		slog.Time("time", id.base.Time()),
+	)
+
+	return slog.GroupValue(attrs...)
+}
+
+// Format implements the [fmt.Formatter] interface.
+//
+// The following verbs are supported:
+//
+//	%s, %v  the base identifier, e.g. "000034o1ibe7u02570ak9evj9s"
+//	%q      the quoted base identifier
+//	%x, %X  the hex encoded base identifier
+//	%+v     the base identifier with the creation time
+//	%#v     the Go syntax representation, see [Base.GoString]
+func (base Base) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			writeFormat(f, base.GoString())
+		case f.Flag('+'):
+			writeFormat(f, verbose(base.String(), base))
+		default:
+			writeFormat(f, base.String())
+		}
+	case 's':
+		writeFormat(f, base.String())
+	case 'q':
+		writeFormat(f, strconv.Quote(base.String()))
+	case 'x':
+		writeFormat(f, hex.EncodeToString(base[:]))
+	case 'X':
+		writeFormat(f, fmt.Sprintf("%X", base[:]))
+	default:
+		fmt.Fprintf(f, "%%!%c(nid.Base=%s)", verb, base.String())
+	}
+}
+
+// GoString returns the Go syntax representation of the [Base].
+func (base Base) GoString() string {
+	if base.Empty() {
+		return "nid.Base{}"
+	}
+
+	return "nid.MustParseBase(" + strconv.Quote(base.String()) + ")"
+}
+
+// LogValue implements the [slog.LogValuer] interface.
+// The [Base] is logged as a group of its text and creation time.
+func (base Base) LogValue() slog.Value {
+	if base.Empty() {
+		return slog.StringValue("")
+	}
+
+	return slog.GroupValue(
+		slog.String("base", base.String()),
+		slog.Time("time", base.Time()),
+	)
+}
+
+// verbose returns the string with the creation time of the [Base].
+func verbose(str string, base Base) string {
+	if base.Empty() {
+		return str
+	}
+
+	return str + " (" + base.Time().UTC().Format(time.RFC3339Nano) + ")"
+}
+
+// writeFormat writes the string respecting the width and alignment flags.
+func writeFormat(f fmt.State, str string) {
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), str)
+}
@@ -0,0 +1,186 @@
+package nid_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNIDFormat(t *testing.T) {
+	id := nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+
+	tt := []struct {
+		name   string
+		format string
+		id     nid.NID
+		want   string
+	}{
+		{
+			name:   "v",
+			format: "%v",
+			id:     id,
+			want:   "book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:   "s_width",
+			format: "%-32s|",
+			id:     id,
+			want:   "book_000034o1ibe7u02570ak9evj9s |",
+		},
+		{
+			name:   "q",
+			format: "%q",
+			id:     id,
+			want:   "\"book_000034o1ibe7u02570ak9evj9s\"",
+		},
+		{
+			name:   "b",
+			format: "%b",
+			id:     id,
+			want:   "000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:   "x",
+			format: "%x",
+			id:     id,
+			want:   "000001930192dc7f0045381544bbf34f",
+		},
+		{
+			name:   "X",
+			format: "%X",
+			id:     id,
+			want:   "000001930192DC7F0045381544BBF34F",
+		},
+		{
+			name:   "plus_v",
+			format: "%+v",
+			id:     id,
+			want:   "book_000034o1ibe7u02570ak9evj9s (2024-11-06T13:03:42.207Z)",
+		},
+		{
+			name:   "plus_v_empty",
+			format: "%+v",
+			id:     nid.NID{},
+			want:   "",
+		},
+		{
+			name:   "sharp_v",
+			format: "%#v",
+			id:     id,
+			want:   `nid.MustParse("book_000034o1ibe7u02570ak9evj9s")`,
+		},
+		{
+			name:   "sharp_v_empty",
+			format: "%#v",
+			id:     nid.NID{},
+			want:   "nid.NID{}",
+		},
+		{
+			name:   "unknown",
+			format: "%d",
+			id:     id,
+			want:   "%!d(nid.NID=book_000034o1ibe7u02570ak9evj9s)",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.id); got != tc.want {
+				t.Errorf("fmt.Sprintf(%q, NID) = %s; want = %s", tc.format, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBaseFormat(t *testing.T) {
+	base := nid.MustParseBase("000034o1ibe7u02570ak9evj9s")
+
+	tt := []struct {
+		name   string
+		format string
+		base   nid.Base
+		want   string
+	}{
+		{
+			name:   "v",
+			format: "%v",
+			base:   base,
+			want:   "000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:   "q",
+			format: "%q",
+			base:   base,
+			want:   "\"000034o1ibe7u02570ak9evj9s\"",
+		},
+		{
+			name:   "x",
+			format: "%x",
+			base:   base,
+			want:   "000001930192dc7f0045381544bbf34f",
+		},
+		{
+			name:   "plus_v",
+			format: "%+v",
+			base:   base,
+			want:   "000034o1ibe7u02570ak9evj9s (2024-11-06T13:03:42.207Z)",
+		},
+		{
+			name:   "sharp_v",
+			format: "%#v",
+			base:   base,
+			want:   `nid.MustParseBase("000034o1ibe7u02570ak9evj9s")`,
+		},
+		{
+			name:   "sharp_v_empty",
+			format: "%#v",
+			base:   nid.Base{},
+			want:   "nid.Base{}",
+		},
+		{
+			name:   "unknown",
+			format: "%d",
+			base:   base,
+			want:   "%!d(nid.Base=000034o1ibe7u02570ak9evj9s)",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.base); got != tc.want {
+				t.Errorf("fmt.Sprintf(%q, Base) = %s; want = %s", tc.format, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
+				a.Value = slog.TimeValue(a.Value.Time().UTC())
+			}
+
+			return a
+		},
+	}))
+
+	logger.Info("created",
+		"id", nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+		"base", nid.MustParseBase("000034o1ibe7u02570ak9evj9s"),
+		"empty", nid.NID{},
+	)
+
+	want := "id.name=book id.base=000034o1ibe7u02570ak9evj9s id.time=2024-11-06T13:03:42.207Z " +
+		"base.base=000034o1ibe7u02570ak9evj9s base.time=2024-11-06T13:03:42.207Z empty=\"\"\n"
+
+	got := buf.String()
+	if got = got[bytes.Index(buf.Bytes(), []byte("id.name")):]; got != want {
+		t.Errorf("slog output = %s; want = %s", got, want)
+	}
+}
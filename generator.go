@@ -0,0 +1,353 @@
+package nid
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNodeBits     = 10
+	defaultSequenceBits = 12
+)
+
+// Exhaustion is the policy of the [Generator] when the sequence of the current time tick is exhausted.
+type Exhaustion int
+
+const (
+	// ExhaustionWait blocks until the clock moves to the next time tick.
+	ExhaustionWait Exhaustion = iota
+	// ExhaustionError returns the [ErrSequenceExhausted] error.
+	ExhaustionError
+)
+
+// Regression is the policy of the [Generator] when the clock moves backwards.
+type Regression int
+
+const (
+	// RegressionHold keeps using the last time until the clock catches up,
+	// so the [Base]s still increase at the cost of the time accuracy.
+	RegressionHold Regression = iota
+	// RegressionWait blocks until the clock catches up with the last time.
+	RegressionWait
+	// RegressionError returns the [ErrClockRegression] error.
+	RegressionError
+)
+
+// GeneratorOption configures the [Generator].
+type GeneratorOption func(g *Generator) error
+
+// Generator creates the [Base]s that are unique without relying on the randomness, like Snowflake identifiers.
+// The random part of the [Base] starts with the node ID followed by the sequence number within the time tick
+// of the [Layout], e.g. a millisecond. The remaining bits are still random.
+//
+// The [Base]s created by the [Generator]s with distinct node IDs never collide,
+// and the [Base]s created by the same [Generator] strictly increase, see [Regression] for the clock moving backwards.
+// The [Generator] is safe for concurrent use.
+type Generator struct {
+	mu       sync.Mutex
+	layout   Layout
+	node     uint64
+	nodeBits uint
+	seqBits  uint
+	exhaust  Exhaustion
+	regress  Regression
+	onSkew   func(skew time.Duration)
+	clock    func() time.Time
+	sleep    func(time.Duration)
+	last     uint64
+	lastTime time.Time
+	seq      uint64
+	started  bool
+	behind   bool
+	skews    uint64
+}
+
+// NewGenerator creates a new [Generator] for the node ID, e.g. a region or a worker.
+// By default it uses the [LayoutDefault], 10 bits for the node ID and 12 bits for the sequence,
+// allowing 1024 nodes creating up to 4096 identifiers per millisecond each.
+func NewGenerator(node uint64, opts ...GeneratorOption) (*Generator, error) {
+	g := &Generator{
+		node:     node,
+		nodeBits: defaultNodeBits,
+		seqBits:  defaultSequenceBits,
+		clock:    time.Now,
+		sleep:    time.Sleep,
+	}
+
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return nil, err
+		}
+	}
+
+	if bits := g.nodeBits + g.seqBits; bits > 64 || bits > g.layout.randBits() {
+		return nil, fmt.Errorf("%w: node and sequence bits exceed the random part of the %s layout: %d",
+			ErrInvalidOption, g.layout, bits)
+	} else if node > mask(g.nodeBits) {
+		return nil, fmt.Errorf("%w: node must be less than %d: %d", ErrInvalidOption, uint64(1)<<g.nodeBits, node)
+	}
+
+	return g, nil
+}
+
+// MustGenerator is a helper to create the [Generator]. It panics if the options are invalid.
+func MustGenerator(node uint64, opts ...GeneratorOption) *Generator {
+	g, err := NewGenerator(node, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+// WithNodeBits sets the number of bits of the node ID.
+func WithNodeBits(bits int) GeneratorOption {
+	return func(g *Generator) error {
+		if bits < 0 || bits > 64 {
+			return fmt.Errorf("%w: node bits must be in range [0, 64]: %d", ErrInvalidOption, bits)
+		}
+
+		g.nodeBits = uint(bits)
+
+		return nil
+	}
+}
+
+// WithSequenceBits sets the number of bits of the sequence within the time tick.
+func WithSequenceBits(bits int) GeneratorOption {
+	return func(g *Generator) error {
+		if bits < 1 || bits > 64 {
+			return fmt.Errorf("%w: sequence bits must be in range [1, 64]: %d", ErrInvalidOption, bits)
+		}
+
+		g.seqBits = uint(bits)
+
+		return nil
+	}
+}
+
+// WithGeneratorLayout sets the [Layout] of the [Base]s created by the [Generator].
+// The time tick of the sequence is the precision of the [Layout].
+func WithGeneratorLayout(l Layout) GeneratorOption {
+	return func(g *Generator) error {
+		if !l.valid() {
+			return fmt.Errorf("%w: unknown layout: %d", ErrInvalidOption, l)
+		}
+
+		g.layout = l
+
+		return nil
+	}
+}
+
+// WithExhaustion sets the [Exhaustion] policy of the [Generator].
+func WithExhaustion(policy Exhaustion) GeneratorOption {
+	return func(g *Generator) error {
+		if policy != ExhaustionWait && policy != ExhaustionError {
+			return fmt.Errorf("%w: unknown exhaustion policy: %d", ErrInvalidOption, policy)
+		}
+
+		g.exhaust = policy
+
+		return nil
+	}
+}
+
+// WithRegression sets the [Regression] policy of the [Generator].
+func WithRegression(policy Regression) GeneratorOption {
+	return func(g *Generator) error {
+		if policy != RegressionHold && policy != RegressionWait && policy != RegressionError {
+			return fmt.Errorf("%w: unknown regression policy: %d", ErrInvalidOption, policy)
+		}
+
+		g.regress = policy
+
+		return nil
+	}
+}
+
+// WithSkewHook sets the function called with the skew every time the [Generator] detects the clock moving backwards,
+// e.g. to report a metric. It's called once per regression, not for every [Base] created until the clock catches up.
+// It's called while the [Generator] is locked, so it must not use the [Generator].
+func WithSkewHook(hook func(skew time.Duration)) GeneratorOption {
+	return func(g *Generator) error {
+		g.onSkew = hook
+
+		return nil
+	}
+}
+
+// WithClock sets the clock of the [Generator], e.g. to use a fake clock in tests.
+func WithClock(clock func() time.Time) GeneratorOption {
+	return func(g *Generator) error {
+		if clock == nil {
+			return fmt.Errorf("%w: clock must not be nil", ErrInvalidOption)
+		}
+
+		g.clock = clock
+
+		return nil
+	}
+}
+
+// WithGenerator sets the [Generator] used by the [Naming.New] and the [Naming.Generate].
+// The [Naming.NewAt] doesn't use the [Generator], since its uniqueness relies on the clock,
+// but it creates the [Base]s of the [Generator] [Layout]. The [WithLayout], if set, must match it.
+func WithGenerator(g *Generator) Option {
+	return func(cfg *config) error {
+		if g == nil {
+			return fmt.Errorf("%w: generator must not be nil", ErrInvalidOption)
+		}
+
+		cfg.generator = g
+
+		return nil
+	}
+}
+
+// Generate creates a new [NID] at the current time using the [Generator] set by the [WithGenerator].
+// Without the [Generator] it's the same as the [Naming.New] and never fails.
+func (n Naming) Generate() (NID, error) {
+	n.initialized()
+
+	if n.cfg == nil || n.cfg.generator == nil {
+		return n.NewAt(time.Now()), nil
+	}
+
+	base, err := n.cfg.generator.New()
+	if err != nil {
+		return NID{}, err
+	}
+
+	return NID{namespace: n.namespace, name: n.name, sep: n.sep(), base: base}, nil
+}
+
+// Layout returns the [Layout] of the [Base]s created by the [Generator].
+func (g *Generator) Layout() Layout {
+	return g.layout
+}
+
+// New creates a new [Base] at the current time of the clock.
+// It returns the [ErrSequenceExhausted] error if the sequence is exhausted and the policy is [ExhaustionError],
+// or the [ErrClockRegression] error if the clock moved backwards and the policy is [RegressionError].
+func (g *Generator) New() (Base, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ts := g.clock()
+	units := g.layout.unitsAt(ts)
+
+	if g.started && units < g.last {
+		skew := time.Duration(g.last-units) * g.layout.Precision() //nolint:gosec
+
+		g.report(skew)
+
+		switch g.regress {
+		case RegressionError:
+			return Base{}, fmt.Errorf("%w: clock moved backwards by %s", ErrClockRegression, skew)
+		case RegressionWait:
+			for units < g.last {
+				g.sleep(time.Duration(g.last-units) * g.layout.Precision()) //nolint:gosec
+
+				ts = g.clock()
+				units = g.layout.unitsAt(ts)
+			}
+
+			g.behind = false
+		case RegressionHold:
+			ts, units = g.lastTime, g.last
+		}
+	} else {
+		g.behind = false
+	}
+
+	if g.started && units == g.last && g.seq == mask(g.seqBits) {
+		if g.exhaust == ExhaustionError {
+			return Base{}, fmt.Errorf("%w: %d identifiers at %s", ErrSequenceExhausted, g.seq+1, ts.Format(time.RFC3339Nano))
+		}
+
+		for units <= g.last {
+			g.sleep(g.layout.Precision())
+
+			ts = g.clock()
+			units = g.layout.unitsAt(ts)
+		}
+	}
+
+	if g.started && units == g.last {
+		g.seq++
+	} else {
+		g.last, g.lastTime, g.seq, g.started = units, ts, 0, true
+	}
+
+	return g.compose(g.layout.NewBaseAt(ts)), nil
+}
+
+// MustNew is a helper to create a new [Base]. It panics if the sequence is exhausted.
+func (g *Generator) MustNew() Base {
+	base, err := g.New()
+	if err != nil {
+		panic(err)
+	}
+
+	return base
+}
+
+// Regressions returns the number of times the [Generator] detected the clock moving backwards.
+// A regression is counted once, however many [Base]s are created until the clock catches up.
+func (g *Generator) Regressions() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.skews
+}
+
+// Node returns the node ID of the [Base] created by the [Generator] with the same options.
+func (g *Generator) Node(base Base) uint64 {
+	return g.field(base) >> g.seqBits
+}
+
+// Sequence returns the sequence number of the [Base] created by the [Generator] with the same options.
+func (g *Generator) Sequence(base Base) uint64 {
+	return g.field(base) & mask(g.seqBits)
+}
+
+// report the regression with the skew unless the clock is still behind since the last reported one.
+func (g *Generator) report(skew time.Duration) {
+	if g.behind {
+		return
+	}
+
+	g.behind = true
+	g.skews++
+
+	if g.onSkew != nil {
+		g.onSkew(skew)
+	}
+}
+
+// compose replaces the top of the random part of the [Base] with the node ID and the sequence number.
+func (g *Generator) compose(base Base) Base {
+	var (
+		bits  = g.nodeBits + g.seqBits
+		shift = g.layout.randBits() - bits
+		field = g.node<<g.seqBits | g.seq
+	)
+
+	hi, lo := base.uint128()
+	mhi, mlo := shl128(0, mask(bits), shift)
+	fhi, flo := shl128(0, field, shift)
+
+	return fromUint128(hi&^mhi|fhi, lo&^mlo|flo)
+}
+
+// field returns the node ID and the sequence number of the [Base] as a single number.
+func (g *Generator) field(base Base) uint64 {
+	bits := g.nodeBits + g.seqBits
+
+	hi, lo := base.uint128()
+	_, field := shr128(hi, lo, g.layout.randBits()-bits)
+
+	return field & mask(bits)
+}
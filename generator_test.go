@@ -0,0 +1,403 @@
+package nid_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+// fakeClock returns the times in order, repeating the last one.
+func fakeClock(times ...time.Time) func() time.Time {
+	return func() time.Time {
+		ts := times[0]
+		if len(times) > 1 {
+			times = times[1:]
+		}
+
+		return ts
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	tt := []struct {
+		name    string
+		node    uint64
+		opts    []nid.GeneratorOption
+		wantErr error
+	}{
+		{
+			name: "default",
+			node: 1023,
+		},
+		{
+			name:    "node_overflow",
+			node:    1024,
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name: "custom_bits",
+			node: 3,
+			opts: []nid.GeneratorOption{nid.WithNodeBits(2), nid.WithSequenceBits(20)},
+		},
+		{
+			name: "no_node",
+			opts: []nid.GeneratorOption{nid.WithNodeBits(0)},
+		},
+		{
+			name:    "bits_exceed_random_part",
+			opts:    []nid.GeneratorOption{nid.WithNodeBits(40), nid.WithSequenceBits(25)},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "bits_exceed_nano_layout",
+			opts:    []nid.GeneratorOption{nid.WithGeneratorLayout(nid.LayoutNano), nid.WithNodeBits(30), nid.WithSequenceBits(31)},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "invalid_sequence_bits",
+			opts:    []nid.GeneratorOption{nid.WithSequenceBits(0)},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "invalid_layout",
+			opts:    []nid.GeneratorOption{nid.WithGeneratorLayout(nid.Layout(42))},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "invalid_exhaustion",
+			opts:    []nid.GeneratorOption{nid.WithExhaustion(nid.Exhaustion(42))},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "invalid_regression",
+			opts:    []nid.GeneratorOption{nid.WithRegression(nid.Regression(42))},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "nil_clock",
+			opts:    []nid.GeneratorOption{nid.WithClock(nil)},
+			wantErr: nid.ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nid.NewGenerator(tc.node, tc.opts...)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Errorf("NewGenerator() err = %v; want = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneratorNew(t *testing.T) {
+	ts := time.Date(2024, time.November, 6, 13, 3, 42, 207_000_000, time.UTC)
+
+	tt := []struct {
+		name   string
+		node   uint64
+		layout nid.Layout
+	}{
+		{
+			name:   "default",
+			node:   42,
+			layout: nid.LayoutDefault,
+		},
+		{
+			name:   "compact",
+			node:   1023,
+			layout: nid.LayoutCompact,
+		},
+		{
+			name:   "nano",
+			node:   7,
+			layout: nid.LayoutNano,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g := nid.MustGenerator(tc.node, nid.WithGeneratorLayout(tc.layout), nid.WithClock(fakeClock(ts)))
+
+			var prev nid.Base
+
+			for i := range 100 {
+				base := g.MustNew()
+
+				if got := g.Node(base); got != tc.node {
+					t.Errorf("Generator.Node() = %d; want = %d", got, tc.node)
+				}
+
+				if got := g.Sequence(base); got != uint64(i) {
+					t.Errorf("Generator.Sequence() = %d; want = %d", got, i)
+				}
+
+				if got := base.Time(); !got.Equal(ts) || base.Layout() != tc.layout {
+					t.Errorf("Generator.New() = %v (%s); want = %v (%s)", got, base.Layout(), ts, tc.layout)
+				}
+
+				if nid.CompareBase(prev, base) >= 0 {
+					t.Fatalf("Generator.New() = %s; want greater than %s", base, prev)
+				}
+
+				prev = base
+			}
+		})
+	}
+}
+
+func TestGeneratorNodes(t *testing.T) {
+	clock := nid.WithClock(fakeClock(time.UnixMilli(12345)))
+	a, b := nid.MustGenerator(1, clock), nid.MustGenerator(2, clock)
+
+	seen := make(map[nid.Base]struct{})
+
+	for range 1000 {
+		for _, g := range []*nid.Generator{a, b} {
+			base := g.MustNew()
+			if _, ok := seen[base]; ok {
+				t.Fatalf("Generator.New() = %s; want unique", base)
+			}
+
+			seen[base] = struct{}{}
+		}
+	}
+}
+
+func TestGeneratorExhaustion(t *testing.T) {
+	ts := time.UnixMilli(12345)
+
+	t.Run("error", func(t *testing.T) {
+		g := nid.MustGenerator(0,
+			nid.WithSequenceBits(2),
+			nid.WithExhaustion(nid.ExhaustionError),
+			nid.WithClock(fakeClock(ts)),
+		)
+
+		for range 4 {
+			g.MustNew()
+		}
+
+		if _, err := g.New(); !errors.Is(err, nid.ErrSequenceExhausted) {
+			t.Errorf("Generator.New() err = %v; want = %v", err, nid.ErrSequenceExhausted)
+		}
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		g := nid.MustGenerator(0,
+			nid.WithSequenceBits(2),
+			nid.WithClock(fakeClock(ts, ts, ts, ts, ts, ts, ts.Add(time.Millisecond))),
+		)
+
+		for range 4 {
+			g.MustNew()
+		}
+
+		base, err := g.New()
+		if err != nil {
+			t.Fatalf("Generator.New() unexpected err = %v", err)
+		}
+
+		if got, want := base.Time(), ts.Add(time.Millisecond); !got.Equal(want) || g.Sequence(base) != 0 {
+			t.Errorf("Generator.New() = %v #%d; want = %v #0", got, g.Sequence(base), want)
+		}
+	})
+}
+
+func TestGeneratorRegression(t *testing.T) {
+	ts := time.Date(2024, time.November, 6, 13, 3, 42, 207_000_000, time.UTC)
+
+	tt := []struct {
+		name     string
+		policy   nid.Regression
+		clock    []time.Time
+		wantTime time.Time
+		wantErr  error
+	}{
+		{
+			name:     "hold",
+			policy:   nid.RegressionHold,
+			clock:    []time.Time{ts, ts.Add(-2 * time.Millisecond)},
+			wantTime: ts,
+		},
+		{
+			name:     "wait",
+			policy:   nid.RegressionWait,
+			clock:    []time.Time{ts, ts.Add(-2 * time.Millisecond), ts.Add(-time.Millisecond), ts.Add(time.Millisecond)},
+			wantTime: ts.Add(time.Millisecond),
+		},
+		{
+			name:    "error",
+			policy:  nid.RegressionError,
+			clock:   []time.Time{ts, ts.Add(-2 * time.Millisecond)},
+			wantErr: nid.ErrClockRegression,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var skews []time.Duration
+
+			g := nid.MustGenerator(1,
+				nid.WithRegression(tc.policy),
+				nid.WithClock(fakeClock(tc.clock...)),
+				nid.WithSkewHook(func(skew time.Duration) {
+					skews = append(skews, skew)
+				}),
+			)
+
+			first := g.MustNew()
+
+			got, err := g.New()
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Fatalf("Generator.New() err = %v; want = %v", err, tc.wantErr)
+			}
+
+			if len(skews) != 1 || skews[0] != 2*time.Millisecond || g.Regressions() != 1 {
+				t.Errorf("skews = %v, Generator.Regressions() = %d; want = [2ms], 1", skews, g.Regressions())
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if !got.Time().Equal(tc.wantTime) {
+				t.Errorf("Generator.New().Time() = %v; want = %v", got.Time(), tc.wantTime)
+			}
+
+			if nid.CompareBase(first, got) >= 0 {
+				t.Errorf("Generator.New() = %s; want greater than %s", got, first)
+			}
+		})
+	}
+}
+
+func TestGeneratorRegressionEvents(t *testing.T) {
+	ts := time.Date(2024, time.November, 6, 13, 3, 42, 0, time.UTC)
+
+	policies := map[string]nid.Regression{
+		"hold":  nid.RegressionHold,
+		"error": nid.RegressionError,
+	}
+
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			var (
+				now   = ts
+				hooks int
+			)
+
+			g := nid.MustGenerator(1,
+				nid.WithRegression(policy),
+				nid.WithClock(func() time.Time { return now }),
+				nid.WithSkewHook(func(time.Duration) { hooks++ }),
+			)
+
+			g.MustNew()
+
+			now = ts.Add(-time.Second)
+			for range 100 {
+				_, _ = g.New()
+			}
+
+			if hooks != 1 || g.Regressions() != 1 {
+				t.Errorf("hooks = %d, Generator.Regressions() = %d; want = 1, 1", hooks, g.Regressions())
+			}
+
+			now = ts.Add(time.Millisecond)
+			g.MustNew()
+
+			now = ts
+			for range 100 {
+				_, _ = g.New()
+			}
+
+			if hooks != 2 || g.Regressions() != 2 {
+				t.Errorf("hooks = %d, Generator.Regressions() = %d; want = 2, 2", hooks, g.Regressions())
+			}
+		})
+	}
+}
+
+func TestNamingGenerate(t *testing.T) {
+	g := nid.MustGenerator(5,
+		nid.WithSequenceBits(1),
+		nid.WithExhaustion(nid.ExhaustionError),
+		nid.WithClock(fakeClock(time.UnixMilli(12345))),
+	)
+	naming := mustScope(nid.MustNaming("book", nid.WithGenerator(g)), "acme")
+
+	id := naming.New()
+	if !naming.Is(id) || g.Node(id.Base()) != 5 {
+		t.Errorf("Naming.New() = %v; want node 5 of %s", id, naming.Name())
+	}
+
+	if _, err := naming.Generate(); err != nil {
+		t.Errorf("Naming.Generate() unexpected err = %v", err)
+	}
+
+	if _, err := naming.Generate(); !errors.Is(err, nid.ErrSequenceExhausted) {
+		t.Errorf("Naming.Generate() err = %v; want = %v", err, nid.ErrSequenceExhausted)
+	}
+
+	if _, err := nid.NewNaming("book", nid.WithGenerator(nil)); !errors.Is(err, nid.ErrInvalidOption) {
+		t.Errorf("NewNaming() err = %v; want = %v", err, nid.ErrInvalidOption)
+	}
+}
+
+func TestNamingGeneratorLayout(t *testing.T) {
+	micro := nid.MustGenerator(1, nid.WithGeneratorLayout(nid.LayoutMicro))
+
+	tt := []struct {
+		name    string
+		opts    []nid.Option
+		want    nid.Layout
+		wantErr error
+	}{
+		{
+			name: "generator_only",
+			opts: []nid.Option{nid.WithGenerator(micro)},
+			want: nid.LayoutMicro,
+		},
+		{
+			name: "same_layout",
+			opts: []nid.Option{nid.WithLayout(nid.LayoutMicro), nid.WithGenerator(micro)},
+			want: nid.LayoutMicro,
+		},
+		{
+			name:    "mixed_layout",
+			opts:    []nid.Option{nid.WithLayout(nid.LayoutMicro), nid.WithGenerator(nid.MustGenerator(1))},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "explicit_default_layout",
+			opts:    []nid.Option{nid.WithGenerator(micro), nid.WithLayout(nid.LayoutDefault)},
+			wantErr: nid.ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			naming, err := nid.NewNaming("book", tc.opts...)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Fatalf("NewNaming() err = %v; want = %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if got := naming.Layout(); got != tc.want {
+				t.Errorf("Naming.Layout() = %v; want = %v", got, tc.want)
+			}
+
+			for _, id := range append(naming.NewN(2), naming.New(), naming.NewAt(time.Now())) {
+				if got := id.Base().Layout(); got != tc.want {
+					t.Errorf("Base.Layout() = %v; want = %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
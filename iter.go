@@ -0,0 +1,102 @@
+package nid
+
+import "iter"
+
+// Stream returns an unbounded sequence of new [NID]s in strictly ascending order.
+// The [NID]s created within the same time tick are ordered by incrementing the previous [Base] if needed.
+func (n Naming) Stream() iter.Seq[NID] {
+	n.initialized()
+
+	return func(yield func(NID) bool) {
+		var prev NID
+
+		for {
+			id := n.New()
+			if !prev.Empty() && CompareBase(id.base, prev.base) <= 0 {
+				id.base = prev.base.next()
+			}
+
+			if !yield(id) {
+				return
+			}
+
+			prev = id
+		}
+	}
+}
+
+// Filter returns the sequence of the [NID]s matching the [Naming], see [Naming.Is].
+func (n Naming) Filter(seq iter.Seq[NID]) iter.Seq[NID] {
+	n.initialized()
+
+	return func(yield func(NID) bool) {
+		for id := range seq {
+			if n.Is(id) && !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// Merge the sorted sequences of the [NID]s into a single sequence sorted by the [Compare].
+// The equal [NID]s are all kept, in the order of the sequences. See [Sort].
+func Merge(seqs ...iter.Seq[NID]) iter.Seq[NID] {
+	return merge(Compare, seqs)
+}
+
+// MergeBase merges the sorted sequences of the [Base]s into a single sequence sorted by the [CompareBase].
+func MergeBase(seqs ...iter.Seq[Base]) iter.Seq[Base] {
+	return merge(CompareBase, seqs)
+}
+
+// merge picks the smallest head of the sequences on every step, which is fast for a few sequences.
+func merge[T any](cmp func(a, b T) int, seqs []iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var (
+			nexts = make([]func() (T, bool), 0, len(seqs))
+			heads = make([]T, 0, len(seqs))
+		)
+
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+
+			if head, ok := next(); ok {
+				nexts = append(nexts, next)
+				heads = append(heads, head)
+			}
+		}
+
+		for len(heads) > 0 {
+			i := 0
+			for j := 1; j < len(heads); j++ {
+				if cmp(heads[j], heads[i]) < 0 {
+					i = j
+				}
+			}
+
+			if !yield(heads[i]) {
+				return
+			}
+
+			if head, ok := nexts[i](); ok {
+				heads[i] = head
+			} else {
+				nexts = append(nexts[:i], nexts[i+1:]...)
+				heads = append(heads[:i], heads[i+1:]...)
+			}
+		}
+	}
+}
+
+// next returns the [Base] incremented by one.
+func (base Base) next() Base {
+	hi, lo := base.uint128()
+
+	lo++
+	if lo == 0 {
+		hi++
+	}
+
+	return fromUint128(hi, lo)
+}
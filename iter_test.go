@@ -0,0 +1,128 @@
+package nid_test
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNaming_Stream(t *testing.T) {
+	naming := mustScope(nid.MustNaming("book"), "acme")
+
+	var prev nid.NID
+
+	count := 0
+
+	for id := range naming.Stream() {
+		if !naming.Is(id) {
+			t.Fatalf("Naming.Stream() = %v; want naming %s", id, naming.Name())
+		}
+
+		if nid.Compare(prev, id) >= 0 {
+			t.Fatalf("Naming.Stream() = %v; want greater than %v", id, prev)
+		}
+
+		prev = id
+
+		if count++; count == 10_000 {
+			break
+		}
+	}
+}
+
+func TestNaming_Filter(t *testing.T) {
+	var (
+		book  = nid.MustNaming("book")
+		books = book.NewN(3)
+		other = nid.MustNaming("author").NewN(2)
+	)
+
+	list := []nid.NID{other[0], books[0], books[1], other[1], {}, books[2]}
+
+	got := slices.Collect(book.Filter(slices.Values(list)))
+	if !slices.Equal(got, books) {
+		t.Errorf("Naming.Filter() = %v; want = %v", got, books)
+	}
+
+	for id := range book.Filter(slices.Values(list)) {
+		if id != books[0] {
+			t.Errorf("Naming.Filter() first = %v; want = %v", id, books[0])
+		}
+
+		break
+	}
+}
+
+func TestMerge(t *testing.T) {
+	var (
+		a = nid.MustNaming("example").Apply(nid.Base{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+		b = nid.MustNaming("example").Apply(nid.Base{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2})
+		c = nid.MustNaming("example").Apply(nid.Base{1})
+		d = nid.MustNaming("example_a").Apply(nid.Base{1})
+		e = nid.MustNaming("example_b").Apply(nid.Base{1})
+	)
+
+	tt := []struct {
+		name string
+		seqs []iter.Seq[nid.NID]
+		want []nid.NID
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "single",
+			seqs: []iter.Seq[nid.NID]{slices.Values([]nid.NID{a, c})},
+			want: []nid.NID{a, c},
+		},
+		{
+			name: "interleaved",
+			seqs: []iter.Seq[nid.NID]{
+				slices.Values([]nid.NID{a, c, e}),
+				slices.Values([]nid.NID{}),
+				slices.Values([]nid.NID{b, d}),
+			},
+			want: []nid.NID{a, b, c, d, e},
+		},
+		{
+			name: "duplicates",
+			seqs: []iter.Seq[nid.NID]{
+				slices.Values([]nid.NID{a, c}),
+				slices.Values([]nid.NID{c, d}),
+			},
+			want: []nid.NID{a, c, c, d},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Collect(nid.Merge(tc.seqs...)); !slices.Equal(got, tc.want) {
+				t.Errorf("Merge() = %v; want = %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeBase(t *testing.T) {
+	var (
+		left  = []nid.Base{{0, 1}, {0, 3}, {0, 5}}
+		right = []nid.Base{{0, 2}, {0, 4}}
+		want  = []nid.Base{{0, 1}, {0, 2}, {0, 3}}
+	)
+
+	var got []nid.Base
+
+	for base := range nid.MergeBase(slices.Values(left), slices.Values(right)) {
+		got = append(got, base)
+
+		if len(got) == len(want) {
+			break
+		}
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("MergeBase() = %v; want = %v", got, want)
+	}
+}
@@ -0,0 +1,236 @@
+package nid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const tagBits = 4
+
+// Layout defines how the time and random parts are packed into the 16 bytes of the [Base].
+// All layouts share the text and binary encodings, so the [Base]s of any layout are parsed, compared
+// and stored the same way. The [Base]s of the same layout sort by the creation time.
+//
+// The non-default layouts store the layout tag in the top 4 bits of the first byte,
+// so [Base.Layout] and [Base.Time] work without knowing the layout in advance.
+// The tagged [Base]s sort after the default ones, and the times before the Unix epoch are not supported.
+type Layout uint8
+
+const (
+	// LayoutDefault stores the 64-bit time in milliseconds followed by 64 random bits.
+	// The collision probability reaches 50% at about 5·10^9 identifiers created within the same millisecond.
+	LayoutDefault Layout = iota
+	// LayoutCompact stores the 48-bit time in milliseconds, which lasts until the year 10889,
+	// followed by 76 random bits. The collision probability reaches 50% at about 3·10^11 identifiers
+	// created within the same millisecond.
+	LayoutCompact
+	// LayoutMicro stores the 56-bit time in microseconds, which lasts until the year 4253,
+	// followed by 68 random bits. The collision probability reaches 50% at about 2·10^10 identifiers
+	// created within the same microsecond.
+	LayoutMicro
+	// LayoutNano stores the 64-bit time in nanoseconds, which lasts until the year 2262,
+	// followed by 60 random bits. The collision probability reaches 50% at about 10^9 identifiers
+	// created within the same nanosecond.
+	LayoutNano
+)
+
+type layoutSpec struct {
+	name string
+	bits uint
+	unit time.Duration
+}
+
+//nolint:gochecknoglobals
+var layouts = [...]layoutSpec{
+	LayoutDefault: {name: "default", bits: 64, unit: time.Millisecond},
+	LayoutCompact: {name: "compact", bits: 48, unit: time.Millisecond},
+	LayoutMicro:   {name: "micro", bits: 56, unit: time.Microsecond},
+	LayoutNano:    {name: "nano", bits: 64, unit: time.Nanosecond},
+}
+
+// WithLayout sets the [Layout] of the [Base]s created by the [Naming].
+// The [Naming] still parses the [NID]s of any layout, so the layout can be changed for the existing data.
+func WithLayout(l Layout) Option {
+	return func(cfg *config) error {
+		if !l.valid() {
+			return fmt.Errorf("%w: unknown layout: %d", ErrInvalidOption, l)
+		}
+
+		cfg.layout, cfg.layoutSet = l, true
+
+		return nil
+	}
+}
+
+// Layout returns the [Layout] of the [Base]s created by the [Naming].
+// With the [WithGenerator] it's the [Layout] of the [Generator].
+func (n Naming) Layout() Layout {
+	if n.cfg == nil {
+		return LayoutDefault
+	} else if n.cfg.generator != nil {
+		return n.cfg.generator.Layout()
+	}
+
+	return n.cfg.layout
+}
+
+// Layout returns the [Layout] of the [Base] read from its tag.
+func (base Base) Layout() Layout {
+	if l := Layout(base[0] >> (8 - tagBits)); l != LayoutDefault && l.valid() {
+		return l
+	}
+
+	return LayoutDefault
+}
+
+// NewBaseAt creates a new [Base] of the [Layout] for the given time.
+func (l Layout) NewBaseAt(ts time.Time) Base {
+	var dst Base
+
+	readEntropy(dst[baseLen-(l.randBits()+7)/8:])
+
+	return l.put(dst, ts)
+}
+
+// MinBaseAt returns the smallest [Base] of the [Layout] created at the given time.
+func (l Layout) MinBaseAt(ts time.Time) Base {
+	return l.put(Base{}, ts)
+}
+
+// MaxBaseAt returns the largest [Base] of the [Layout] created at the given time.
+func (l Layout) MaxBaseAt(ts time.Time) Base {
+	var dst Base
+
+	for i := range dst {
+		dst[i] = 0xff
+	}
+
+	return l.put(dst, ts)
+}
+
+// Precision returns the precision of the time stored by the [Layout].
+func (l Layout) Precision() time.Duration {
+	return l.spec().unit
+}
+
+// String returns the name of the [Layout].
+func (l Layout) String() string {
+	if !l.valid() {
+		return fmt.Sprintf("Layout(%d)", l)
+	}
+
+	return l.spec().name
+}
+
+// ceil rounds the time up to the precision of the [Layout].
+func (l Layout) ceil(ts time.Time) time.Time {
+	if t := ts.Truncate(l.Precision()); t.Before(ts) {
+		return t.Add(l.Precision())
+	}
+
+	return ts
+}
+
+func (l Layout) valid() bool {
+	return int(l) < len(layouts)
+}
+
+func (l Layout) spec() layoutSpec {
+	return layouts[l]
+}
+
+// randBits returns the number of the random bits at the end of the [Base].
+func (l Layout) randBits() uint {
+	if l == LayoutDefault {
+		return baseLen*8 - l.spec().bits
+	}
+
+	return baseLen*8 - tagBits - l.spec().bits
+}
+
+// put the tag and the time into the [Base], keeping its random bits.
+func (l Layout) put(dst Base, ts time.Time) Base {
+	if l == LayoutDefault {
+		binary.BigEndian.PutUint64(dst[:timeLen], uint64(ts.UnixMilli())) //nolint:gosec
+
+		return dst
+	}
+
+	shift := l.randBits()
+
+	hi, lo := dst.uint128()
+	mhi, mlo := shr128(^uint64(0), ^uint64(0), baseLen*8-shift)
+	thi, tlo := shl128(0, l.unitsAt(ts), shift)
+	hi, lo = hi&mhi|thi, lo&mlo|tlo
+
+	if l != LayoutDefault {
+		hi |= uint64(l) << (64 - tagBits)
+	}
+
+	return fromUint128(hi, lo)
+}
+
+// unitsAt returns the time in the units of the [Layout], truncated to its bits.
+func (l Layout) unitsAt(ts time.Time) uint64 {
+	var units int64
+
+	switch l.Precision() {
+	case time.Millisecond:
+		units = ts.UnixMilli()
+	case time.Microsecond:
+		units = ts.UnixMicro()
+	default:
+		units = ts.UnixNano()
+	}
+
+	return uint64(units) & mask(l.spec().bits) //nolint:gosec
+}
+
+// units returns the time of the [Base] in the units of its [Layout].
+func (base Base) units() (int64, time.Duration) {
+	l := base.Layout()
+	spec := l.spec()
+
+	hi, lo := base.uint128()
+	_, units := shr128(hi, lo, l.randBits())
+
+	return int64(units & mask(spec.bits)), spec.unit //nolint:gosec
+}
+
+func (base Base) uint128() (uint64, uint64) {
+	return binary.BigEndian.Uint64(base[:8]), binary.BigEndian.Uint64(base[8:])
+}
+
+func fromUint128(hi, lo uint64) Base {
+	var dst Base
+
+	binary.BigEndian.PutUint64(dst[:8], hi)
+	binary.BigEndian.PutUint64(dst[8:], lo)
+
+	return dst
+}
+
+func mask(bits uint) uint64 {
+	if bits >= 64 {
+		return ^uint64(0)
+	}
+
+	return 1<<bits - 1
+}
+
+func shl128(hi, lo uint64, n uint) (uint64, uint64) {
+	if n >= 64 {
+		return lo << (n - 64), 0
+	}
+
+	return hi<<n | lo>>(64-n), lo << n
+}
+
+func shr128(hi, lo uint64, n uint) (uint64, uint64) {
+	if n >= 64 {
+		return 0, hi >> (n - 64)
+	}
+
+	return hi >> n, lo>>n | hi<<(64-n)
+}
@@ -0,0 +1,254 @@
+package nid_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+func TestLayoutNewBaseAt(t *testing.T) {
+	ts := time.Date(2024, time.November, 6, 13, 3, 42, 207_123_456, time.UTC)
+
+	tt := []struct {
+		name     string
+		layout   nid.Layout
+		wantTime time.Time
+	}{
+		{
+			name:     "default",
+			layout:   nid.LayoutDefault,
+			wantTime: ts.Truncate(time.Millisecond),
+		},
+		{
+			name:     "compact",
+			layout:   nid.LayoutCompact,
+			wantTime: ts.Truncate(time.Millisecond),
+		},
+		{
+			name:     "micro",
+			layout:   nid.LayoutMicro,
+			wantTime: ts.Truncate(time.Microsecond),
+		},
+		{
+			name:     "nano",
+			layout:   nid.LayoutNano,
+			wantTime: ts,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			base := tc.layout.NewBaseAt(ts)
+
+			if got := base.Layout(); got != tc.layout {
+				t.Errorf("Base.Layout() = %v; want = %v", got, tc.layout)
+			}
+
+			if got := base.Time(); !got.Equal(tc.wantTime) {
+				t.Errorf("Base.Time() = %v; want = %v", got, tc.wantTime)
+			}
+
+			parsed, err := nid.ParseBase(base.String())
+			if err != nil || parsed != base {
+				t.Errorf("ParseBase() = %v, %v; want = %v", parsed, err, base)
+			}
+
+			minBase, maxBase := tc.layout.MinBaseAt(ts), tc.layout.MaxBaseAt(ts)
+			if nid.CompareBase(minBase, base) > 0 || nid.CompareBase(base, maxBase) > 0 {
+				t.Errorf("Layout.NewBaseAt() = %s; want within [%s, %s]", base, minBase, maxBase)
+			}
+
+			if !minBase.Time().Equal(tc.wantTime) || !maxBase.Time().Equal(tc.wantTime) {
+				t.Errorf("Layout.MinBaseAt/MaxBaseAt() time = %v, %v; want = %v", minBase.Time(), maxBase.Time(), tc.wantTime)
+			}
+
+			next := tc.layout.NewBaseAt(ts.Add(tc.layout.Precision()))
+			if nid.CompareBase(base, next) >= 0 {
+				t.Errorf("Layout.NewBaseAt() = %s; want less than %s", base, next)
+			}
+
+			if bucket := nid.Daily(nil).Bucket(base); !bucket.Contains(base) {
+				t.Errorf("Bucket.Contains() = false; want = true")
+			}
+
+			if other := tc.layout.NewBaseAt(ts); other == base {
+				t.Errorf("Layout.NewBaseAt() = %s twice; want random part", base)
+			}
+		})
+	}
+}
+
+func TestLayoutSubMillisecondOrder(t *testing.T) {
+	ts := time.Date(2024, time.November, 6, 13, 3, 42, 207_000_000, time.UTC)
+
+	tt := []struct {
+		name   string
+		layout nid.Layout
+		step   time.Duration
+	}{
+		{
+			name:   "micro",
+			layout: nid.LayoutMicro,
+			step:   time.Microsecond,
+		},
+		{
+			name:   "nano",
+			layout: nid.LayoutNano,
+			step:   time.Nanosecond,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			list := make([]nid.Base, 1000)
+			for i := range list {
+				list[len(list)-1-i] = tc.layout.NewBaseAt(ts.Add(time.Duration(i) * tc.step))
+			}
+
+			nid.SortBase(list)
+
+			for i, base := range list {
+				if want := ts.Add(time.Duration(i) * tc.step); !base.Time().Equal(want) {
+					t.Fatalf("SortBase()[%d].Time() = %v; want = %v", i, base.Time(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestBaseLayout(t *testing.T) {
+	tt := []struct {
+		name string
+		base nid.Base
+		want nid.Layout
+	}{
+		{
+			name: "empty",
+			base: nid.Base{},
+			want: nid.LayoutDefault,
+		},
+		{
+			name: "default",
+			base: nid.MustParseBase("000034o1ibe7u02570ak9evj9s"),
+			want: nid.LayoutDefault,
+		},
+		{
+			name: "before_epoch",
+			base: nid.NewBaseAt(time.UnixMilli(-1)),
+			want: nid.LayoutDefault,
+		},
+		{
+			name: "compact",
+			base: nid.Base{0x10},
+			want: nid.LayoutCompact,
+		},
+		{
+			name: "micro",
+			base: nid.Base{0x2f, 0xff},
+			want: nid.LayoutMicro,
+		},
+		{
+			name: "nano",
+			base: nid.Base{0x30},
+			want: nid.LayoutNano,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.base.Layout(); got != tc.want {
+				t.Errorf("Base.Layout() = %v; want = %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithLayout(t *testing.T) {
+	naming, err := nid.NewNaming("book", nid.WithLayout(nid.LayoutCompact))
+	if err != nil {
+		t.Fatalf("NewNaming() unexpected err = %v", err)
+	}
+
+	if got := naming.Layout(); got != nid.LayoutCompact {
+		t.Errorf("Naming.Layout() = %v; want = %v", got, nid.LayoutCompact)
+	}
+
+	id := naming.New()
+	if got := id.Base().Layout(); got != nid.LayoutCompact {
+		t.Errorf("Naming.New().Base().Layout() = %v; want = %v", got, nid.LayoutCompact)
+	}
+
+	parsed, err := naming.Parse(id.String())
+	if err != nil || parsed != id {
+		t.Errorf("Naming.Parse() = %v, %v; want = %v", parsed, err, id)
+	}
+
+	if _, err := nid.NewNaming("book", nid.WithLayout(nid.Layout(42))); !errors.Is(err, nid.ErrInvalidOption) {
+		t.Errorf("NewNaming() err = %v; want = %v", err, nid.ErrInvalidOption)
+	}
+}
+
+func TestLayoutString(t *testing.T) {
+	if got := nid.LayoutCompact.String(); got != "compact" {
+		t.Errorf("Layout.String() = %q; want = %q", got, "compact")
+	}
+
+	if got := nid.Layout(42).String(); got != "Layout(42)" {
+		t.Errorf("Layout.String() = %q; want = %q", got, "Layout(42)")
+	}
+}
+
+func TestLayoutRange(t *testing.T) {
+	year := func(y int) time.Time {
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tt := []struct {
+		name    string
+		layout  nid.Layout
+		inside  []time.Time
+		outside []time.Time
+	}{
+		{
+			name:   "default",
+			layout: nid.LayoutDefault,
+			inside: []time.Time{year(3_000), year(100_000)},
+		},
+		{
+			name:    "compact",
+			layout:  nid.LayoutCompact,
+			inside:  []time.Time{year(3_000), year(10_889)},
+			outside: []time.Time{year(10_900)},
+		},
+		{
+			name:    "micro",
+			layout:  nid.LayoutMicro,
+			inside:  []time.Time{year(3_000), year(4_253)},
+			outside: []time.Time{year(4_260)},
+		},
+		{
+			name:    "nano",
+			layout:  nid.LayoutNano,
+			inside:  []time.Time{year(2_262)},
+			outside: []time.Time{year(2_263), year(3_000)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, ts := range tc.inside {
+				if got := tc.layout.NewBaseAt(ts).Time(); !got.Equal(ts) {
+					t.Errorf("Layout.NewBaseAt(%v).Time() = %v; want = %v", ts, got, ts)
+				}
+			}
+
+			for _, ts := range tc.outside {
+				if got := tc.layout.NewBaseAt(ts).Time(); got.Equal(ts) {
+					t.Errorf("Layout.NewBaseAt(%v).Time() = %v; want out of range", ts, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package nid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const (
+	listMagic   = "nid"
+	listVersion = 1
+	listBases   = 'b'
+	listIDs     = 'i'
+)
+
+// EncodeBases encodes the sorted [Base]s into a compact binary list, e.g. for cursor pages or export manifests.
+//
+// The list starts with the self-describing header: the "nid" magic, the format version and the list kind.
+// Every [Base] is encoded as the varint delta of its first 8 bytes, which is the creation time for the
+// [LayoutDefault], followed by its last 8 bytes, so the identifiers created close in time take about 10 bytes.
+// It returns the [ErrUnsorted] error if the [Base]s are not sorted, see [SortBase].
+func EncodeBases(bases []Base) ([]byte, error) {
+	dst := appendListHeader(make([]byte, 0, len(listMagic)+2+binary.MaxVarintLen64+len(bases)*10), listBases)
+
+	return appendBaseList(dst, bases)
+}
+
+// DecodeBases decodes the [Base]s encoded by the [EncodeBases].
+func DecodeBases(src []byte) ([]Base, error) {
+	r, err := readListHeader(src, listBases)
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := readBaseList(r)
+	if err != nil {
+		return nil, err
+	} else if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: trailing list data", ErrFailedParse)
+	}
+
+	return bases, nil
+}
+
+// EncodeIDs encodes the sorted [NID]s into a compact binary list, see [EncodeBases].
+// The [NID]s are grouped by the namespace, the name and the separator, which are encoded once per group.
+// It returns the [ErrUnsorted] error if the [NID]s are not sorted, see [Sort].
+func EncodeIDs(ids []NID) ([]byte, error) {
+	dst := appendListHeader(make([]byte, 0, len(listMagic)+2+binary.MaxVarintLen64+len(ids)*10), listIDs)
+
+	var groups uint64
+
+	for i := range ids {
+		if i == 0 || !sameName(ids[i-1], ids[i]) {
+			groups++
+		}
+	}
+
+	dst = binary.AppendUvarint(dst, groups)
+
+	bases := make([]Base, 0, len(ids))
+
+	for start := 0; start < len(ids); {
+		end := start + 1
+		for end < len(ids) && sameName(ids[start], ids[end]) {
+			end++
+		}
+
+		if start > 0 && Compare(ids[start-1], ids[start]) > 0 {
+			return nil, fmt.Errorf("%w: %s is after %s", ErrUnsorted, ids[start-1], ids[start])
+		}
+
+		id := ids[start]
+		dst = appendString(dst, id.namespace)
+		dst = appendString(dst, id.name)
+		dst = append(dst, id.sep)
+
+		bases = bases[:0]
+		for _, id := range ids[start:end] {
+			bases = append(bases, id.base)
+		}
+
+		var err error
+		if dst, err = appendBaseList(dst, bases); err != nil {
+			return nil, err
+		}
+
+		start = end
+	}
+
+	return dst, nil
+}
+
+// DecodeIDs decodes the [NID]s encoded by the [EncodeIDs].
+func DecodeIDs(src []byte) ([]NID, error) {
+	r, err := readListHeader(src, listIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := binary.ReadUvarint(r)
+	if err != nil || groups > uint64(r.Len()) {
+		return nil, fmt.Errorf("%w: invalid list groups count", ErrFailedParse)
+	}
+
+	var (
+		ids  []NID
+		prev NID
+	)
+
+	for i := range groups {
+		var id NID
+
+		if id.namespace, err = readString(r); err != nil {
+			return nil, err
+		} else if id.name, err = readString(r); err != nil {
+			return nil, err
+		} else if id.sep, err = r.ReadByte(); err != nil {
+			return nil, fmt.Errorf("%w: invalid list separator", ErrFailedParse)
+		} else if err = validateListGroup(id); err != nil {
+			return nil, err
+		}
+
+		bases, err := readBaseList(r)
+		if err != nil {
+			return nil, err
+		} else if len(bases) == 0 {
+			return nil, fmt.Errorf("%w: empty list group", ErrFailedParse)
+		}
+
+		for _, base := range bases {
+			if base.Empty() != (id.name == "") {
+				return nil, fmt.Errorf("%w: invalid list group base: %s", ErrFailedParse, base)
+			}
+
+			id.base = base
+			ids = append(ids, id)
+		}
+
+		if i > 0 && (sameName(prev, id) || Compare(prev, ids[len(ids)-len(bases)]) > 0) {
+			return nil, fmt.Errorf("%w: unsorted list groups", ErrFailedParse)
+		}
+
+		prev = id
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: trailing list data", ErrFailedParse)
+	}
+
+	return ids, nil
+}
+
+func appendListHeader(dst []byte, kind byte) []byte {
+	return append(append(dst, listMagic...), listVersion, kind)
+}
+
+func readListHeader(src []byte, kind byte) (*bytes.Reader, error) {
+	header := len(listMagic) + 2
+	if len(src) < header || string(src[:len(listMagic)]) != listMagic {
+		return nil, fmt.Errorf("%w: invalid list header", ErrFailedParse)
+	} else if src[len(listMagic)] != listVersion {
+		return nil, fmt.Errorf("%w: unsupported list version: %d", ErrFailedParse, src[len(listMagic)])
+	} else if src[len(listMagic)+1] != kind {
+		return nil, fmt.Errorf("%w: unexpected list kind: %q", ErrFailedParse, src[len(listMagic)+1])
+	}
+
+	return bytes.NewReader(src[header:]), nil
+}
+
+func appendBaseList(dst []byte, bases []Base) ([]byte, error) {
+	dst = binary.AppendUvarint(dst, uint64(len(bases)))
+
+	var prev uint64
+
+	for i, base := range bases {
+		if i > 0 && CompareBase(bases[i-1], base) > 0 {
+			return nil, fmt.Errorf("%w: %s is after %s", ErrUnsorted, bases[i-1], base)
+		}
+
+		high := binary.BigEndian.Uint64(base[:8])
+		dst = binary.AppendUvarint(dst, high-prev)
+		dst = append(dst, base[8:]...)
+
+		prev = high
+	}
+
+	return dst, nil
+}
+
+func readBaseList(r *bytes.Reader) ([]Base, error) {
+	count, err := binary.ReadUvarint(r)
+	if err != nil || count > uint64(r.Len()/9) {
+		return nil, fmt.Errorf("%w: invalid list count", ErrFailedParse)
+	}
+
+	bases := make([]Base, count)
+
+	var high uint64
+
+	for i := range bases {
+		delta, err := binary.ReadUvarint(r)
+		if err != nil || high+delta < high {
+			return nil, fmt.Errorf("%w: invalid list delta", ErrFailedParse)
+		}
+
+		high += delta
+
+		binary.BigEndian.PutUint64(bases[i][:8], high)
+
+		if n, _ := r.Read(bases[i][8:]); n != 8 {
+			return nil, fmt.Errorf("%w: truncated list", ErrFailedParse)
+		} else if i > 0 && CompareBase(bases[i-1], bases[i]) > 0 {
+			return nil, fmt.Errorf("%w: unsorted list", ErrFailedParse)
+		}
+	}
+
+	return bases, nil
+}
+
+func appendString(dst []byte, str string) []byte {
+	return append(binary.AppendUvarint(dst, uint64(len(str))), str...)
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil || size > uint64(r.Len()) {
+		return "", fmt.Errorf("%w: invalid list string", ErrFailedParse)
+	}
+
+	buf := make([]byte, size)
+	_, _ = r.Read(buf)
+
+	return string(buf), nil
+}
+
+// validateListGroup checks the namespace, the name and the separator of the decoded group.
+// The name rules of the [Naming]s are unknown, so the name only must be non-empty without dots and spaces.
+func validateListGroup(id NID) error {
+	switch {
+	case id.name == "" && (id.namespace != "" || id.sep != 0):
+		return fmt.Errorf("%w: empty list group name", ErrFailedParse)
+	case strings.ContainsAny(id.name, ". "):
+		return fmt.Errorf("%w: invalid list group name: %q", ErrFailedParse, id.name)
+	case id.namespace != "" && !validateNamespace(id.namespace):
+		return fmt.Errorf("%w: invalid list group namespace: %q", ErrFailedParse, id.namespace)
+	case id.sep == defaultSep || (id.sep != 0 && strings.IndexByte(separators, id.sep) < 0):
+		return fmt.Errorf("%w: invalid list group separator: %q", ErrFailedParse, id.sep)
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package nid_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+func TestEncodeBases(t *testing.T) {
+	sorted := newBases(1000, time.Minute)
+
+	tt := []struct {
+		name    string
+		bases   []nid.Base
+		wantErr error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "sorted",
+			bases: sorted,
+		},
+		{
+			name:  "duplicates",
+			bases: []nid.Base{{}, {0, 1}, {0, 1}, {0, 2}},
+		},
+		{
+			name:  "tagged_layouts",
+			bases: nid.DedupBase([]nid.Base{nid.NewBase(), nid.LayoutCompact.NewBaseAt(time.Now()), nid.LayoutNano.NewBaseAt(time.Now())}),
+		},
+		{
+			name:    "unsorted",
+			bases:   []nid.Base{{0, 2}, {0, 1}},
+			wantErr: nid.ErrUnsorted,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := nid.EncodeBases(tc.bases)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Fatalf("EncodeBases() err = %v; want = %v", err, tc.wantErr)
+			} else if err != nil {
+				return
+			}
+
+			got, err := nid.DecodeBases(data)
+			if err != nil {
+				t.Fatalf("DecodeBases() unexpected err = %v", err)
+			}
+
+			if !slices.Equal(got, tc.bases) {
+				t.Errorf("DecodeBases() = %v; want = %v", got, tc.bases)
+			}
+		})
+	}
+
+	data, _ := nid.EncodeBases(sorted)
+	if limit := len(sorted) * 10; len(data) > limit {
+		t.Errorf("EncodeBases() len = %d; want at most %d", len(data), limit)
+	}
+}
+
+func TestEncodeIDs(t *testing.T) {
+	var (
+		books   = nid.MustNaming("book").NewN(100)
+		acme    = mustScope(nid.MustNaming("book"), "acme").NewN(10)
+		kebab   = nid.MustNaming("user-profile", nid.WithNameRule(nid.KebabCase), nid.WithSeparator('-')).NewN(10)
+		authors = nid.MustNaming("author").NewN(10)
+	)
+
+	tt := []struct {
+		name    string
+		ids     []nid.NID
+		wantErr error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "single_group",
+			ids:  books,
+		},
+		{
+			name: "many_groups",
+			ids:  nid.Dedup(slices.Concat([]nid.NID{{}}, books, acme, kebab, authors)),
+		},
+		{
+			name:    "unsorted_groups",
+			ids:     slices.Concat(books, authors),
+			wantErr: nid.ErrUnsorted,
+		},
+		{
+			name:    "unsorted_bases",
+			ids:     []nid.NID{books[1], books[0]},
+			wantErr: nid.ErrUnsorted,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := nid.EncodeIDs(tc.ids)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Fatalf("EncodeIDs() err = %v; want = %v", err, tc.wantErr)
+			} else if err != nil {
+				return
+			}
+
+			got, err := nid.DecodeIDs(data)
+			if err != nil {
+				t.Fatalf("DecodeIDs() unexpected err = %v", err)
+			}
+
+			if !slices.Equal(got, tc.ids) {
+				t.Errorf("DecodeIDs() = %v; want = %v", got, tc.ids)
+			}
+		})
+	}
+}
+
+func TestDecodeListErrors(t *testing.T) {
+	bases, _ := nid.EncodeBases(newBases(10, time.Second))
+	ids, _ := nid.EncodeIDs(nid.MustNaming("book").NewN(10))
+
+	tt := []struct {
+		name   string
+		src    []byte
+		decode func([]byte) error
+	}{
+		{
+			name:   "empty",
+			src:    nil,
+			decode: decodeBases,
+		},
+		{
+			name:   "bad_magic",
+			src:    append([]byte("xyz"), bases[3:]...),
+			decode: decodeBases,
+		},
+		{
+			name:   "bad_version",
+			src:    append([]byte("nid\x02"), bases[4:]...),
+			decode: decodeBases,
+		},
+		{
+			name:   "wrong_kind",
+			src:    ids,
+			decode: decodeBases,
+		},
+		{
+			name:   "truncated_bases",
+			src:    bases[:len(bases)-1],
+			decode: decodeBases,
+		},
+		{
+			name:   "trailing_bases",
+			src:    append(slices.Clone(bases), 0),
+			decode: decodeBases,
+		},
+		{
+			name:   "truncated_ids",
+			src:    ids[:len(ids)-1],
+			decode: decodeIDs,
+		},
+		{
+			name:   "default_separator",
+			src:    []byte("nid\x01i\x01\x00\x04book_\x01\x01\x00\x00\x00\x00\x00\x00\x00\x01"),
+			decode: decodeIDs,
+		},
+		{
+			name:   "invalid_name",
+			src:    []byte("nid\x01i\x01\x00\x04bo.k\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x01"),
+			decode: decodeIDs,
+		},
+		{
+			name:   "empty_base",
+			src:    []byte("nid\x01i\x01\x00\x04book\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00"),
+			decode: decodeIDs,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.decode(tc.src); !errors.Is(err, nid.ErrFailedParse) {
+				t.Errorf("decode() err = %v; want = %v", err, nid.ErrFailedParse)
+			}
+		})
+	}
+}
+
+func decodeBases(src []byte) error {
+	_, err := nid.DecodeBases(src)
+
+	return err
+}
+
+func decodeIDs(src []byte) error {
+	_, err := nid.DecodeIDs(src)
+
+	return err
+}
+
+func FuzzEncodeBases(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 32))
+	f.Add([]byte("000034o1ibe7u02570ak9evj9s000034o1ibe7u02570ak9evj9s"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		bases := make([]nid.Base, len(data)/16)
+		for i := range bases {
+			copy(bases[i][:], data[i*16:])
+		}
+
+		nid.SortBase(bases)
+
+		encoded, err := nid.EncodeBases(bases)
+		if err != nil {
+			t.Fatalf("EncodeBases() unexpected err = %v", err)
+		}
+
+		got, err := nid.DecodeBases(encoded)
+		if err != nil {
+			t.Fatalf("DecodeBases() unexpected err = %v", err)
+		}
+
+		if !slices.Equal(got, bases) {
+			t.Errorf("DecodeBases() = %v; want = %v", got, bases)
+		}
+	})
+}
+
+func FuzzDecodeBases(f *testing.F) {
+	data, _ := nid.EncodeBases(newBases(10, time.Second))
+	f.Add(data)
+	f.Add([]byte("nid\x01b\x00"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		bases, err := nid.DecodeBases(data)
+		if err != nil {
+			return
+		}
+
+		encoded, err := nid.EncodeBases(bases)
+		if err != nil {
+			t.Fatalf("EncodeBases() unexpected err = %v", err)
+		}
+
+		if got, err := nid.DecodeBases(encoded); err != nil || !slices.Equal(got, bases) {
+			t.Errorf("DecodeBases() = %v, %v; want = %v", got, err, bases)
+		}
+	})
+}
+
+func FuzzDecodeIDs(f *testing.F) {
+	data, _ := nid.EncodeIDs(nid.Dedup(slices.Concat(
+		nid.MustNaming("book").NewN(3),
+		mustScope(nid.MustNaming("author"), "acme").NewN(3),
+	)))
+	f.Add(data)
+	f.Add([]byte("nid\x01i\x00"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ids, err := nid.DecodeIDs(data)
+		if err != nil {
+			return
+		}
+
+		encoded, err := nid.EncodeIDs(ids)
+		if err != nil {
+			t.Fatalf("EncodeIDs() unexpected err = %v", err)
+		}
+
+		if got, err := nid.DecodeIDs(encoded); err != nil || !slices.Equal(got, ids) {
+			t.Errorf("DecodeIDs() = %v, %v; want = %v", got, err, ids)
+		}
+	})
+}
@@ -2,17 +2,43 @@ package nid
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
 // Naming provides a way to create, update and validate the [NID]s.
+//
+// The [Naming]s created without options, or with the default ones only, are equal with the == operator.
+// The options are shared by the copies of the [Naming], e.g. the scoped ones, so the == operator compares
+// the other [Naming]s by identity. Use the [Naming.Equal] to compare them by value.
 type Naming struct {
-	name string
+	namespace string
+	name      string
+	cfg       *config
 }
 
-// MustNaming is a helper to create Namer from the name. It panics if the name is invalid.
-func MustNaming(name string) Naming {
-	n, err := NewNaming(name)
+// config of the [Naming] set by the [Option]s.
+type config struct {
+	storage   Storage
+	cipher    *Cipher
+	sep       byte
+	rule      NameRule
+	maxLen    int
+	aliases   []string
+	canon     bool
+	report    func(legacy, canonical NID)
+	layout    Layout
+	layoutSet bool
+	generator *Generator
+}
+
+// Option configures the [Naming].
+type Option func(*config) error
+
+// MustNaming is a helper to create Namer from the name. It panics if the name or options are invalid.
+func MustNaming(name string, opts ...Option) Naming {
+	n, err := NewNaming(name, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -20,14 +46,89 @@ func MustNaming(name string) Naming {
 	return n
 }
 
-// NewNaming creates a new [Naming] from the name. It returns an error if the name is invalid.
-// The name must be a non-empty snake_case string, e.g. "user" or "user_profile".
-func NewNaming(name string) (Naming, error) {
-	if !validateName(name) {
-		return Naming{}, fmt.Errorf("%w: must be a non-empty snake_case string: %s", ErrInvalidName, name)
+// NewNaming creates a new [Naming] from the name. It returns an error if the name or options are invalid.
+// By default, the name must be a non-empty snake_case string, e.g. "user" or "user_profile".
+// It can be changed with the [WithNameRule] and [WithMaxNameLength] options.
+func NewNaming(name string, opts ...Option) (Naming, error) {
+	cfg := &config{}
+
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return Naming{}, err
+		}
+	}
+
+	if err := cfg.validate(name); err != nil {
+		return Naming{}, err
+	} else if cfg.maxLen > 0 && len(name) > cfg.maxLen {
+		return Naming{}, fmt.Errorf("%w: must be at most %d characters long: %s", ErrInvalidName, cfg.maxLen, name)
 	}
 
-	return Naming{name}, nil
+	if cfg.layoutSet && cfg.generator != nil && cfg.generator.Layout() != cfg.layout {
+		return Naming{}, fmt.Errorf("%w: layout %s doesn't match the %s layout of the generator",
+			ErrInvalidOption, cfg.layout, cfg.generator.Layout())
+	}
+
+	for i, alias := range cfg.aliases {
+		if err := cfg.validate(alias); err != nil {
+			return Naming{}, fmt.Errorf("alias: %w", err)
+		} else if alias == name || slices.Contains(cfg.aliases[:i], alias) {
+			return Naming{}, fmt.Errorf("%w: duplicate alias: %s", ErrInvalidName, alias)
+		}
+	}
+
+	if cfg.equal(nil) {
+		cfg = nil
+	}
+
+	return Naming{name: name, cfg: cfg}, nil
+}
+
+// Equal returns true if the [Naming]s have the same name, namespace and options.
+// The options holding a function, e.g. the [WithNameRule], are equal only if they're shared by the copies
+// of the same [Naming], while the [Cipher] and the [Generator] are compared by identity.
+func (n Naming) Equal(other Naming) bool {
+	return n.namespace == other.namespace && n.name == other.name && n.cfg.equal(other.cfg)
+}
+
+// equal returns true if the configs are the same. A nil config has the default options.
+func (cfg *config) equal(other *config) bool {
+	if cfg == other {
+		return true
+	} else if cfg == nil {
+		cfg = &config{}
+	} else if other == nil {
+		other = &config{}
+	}
+
+	return cfg.storage == other.storage && cfg.cipher == other.cipher && cfg.sep == other.sep &&
+		cfg.rule == nil && other.rule == nil && cfg.maxLen == other.maxLen &&
+		slices.Equal(cfg.aliases, other.aliases) && cfg.canon == other.canon &&
+		cfg.report == nil && other.report == nil && cfg.layout == other.layout && cfg.generator == other.generator
+}
+
+// validate the name with the [NameRule].
+func (cfg *config) validate(name string) error {
+	if cfg.rule == nil && !validateName(name) {
+		return fmt.Errorf("%w: must be a non-empty snake_case string: %s", ErrInvalidName, name)
+	} else if cfg.rule != nil && (name == "" || strings.ContainsAny(name, ". ") || !cfg.rule(name)) {
+		return fmt.Errorf("%w: doesn't match the name rule: %s", ErrInvalidName, name)
+	}
+
+	return nil
+}
+
+// WithStorage sets the [Storage] format used by the [Naming.Column].
+func WithStorage(storage Storage) Option {
+	return func(cfg *config) error {
+		if !storage.valid() {
+			return fmt.Errorf("%w: unknown storage format: %s", ErrInvalidOption, storage)
+		}
+
+		cfg.storage = storage
+
+		return nil
+	}
 }
 
 // initialized the [Naming] has a name.
@@ -37,14 +138,51 @@ func (n Naming) initialized() {
 	}
 }
 
-// New creates a new [NID] at the current time.
-func (n Naming) New() NID {
+// Name returns the name of the [Naming].
+func (n Naming) Name() string {
+	return n.name
+}
+
+// Namespace returns the namespace of the [Naming] or an empty string if the [Naming] is not scoped.
+func (n Naming) Namespace() string {
+	return n.namespace
+}
+
+// Scope returns a copy of the [Naming] that creates the [NID]s in the given namespace, e.g. a tenant.
+// The namespace must be a dot-separated list of snake_case strings, e.g. "acme" or "acme.eu".
+// An empty namespace returns the unscoped [Naming].
+func (n Naming) Scope(namespace string) (Naming, error) {
 	n.initialized()
 
-	return NID{
-		name: n.name,
-		base: NewBase(),
+	if namespace != "" && !validateNamespace(namespace) {
+		return Naming{}, fmt.Errorf("%w: namespace must be a dot-separated list of snake_case strings: %s", ErrInvalidName, namespace)
 	}
+
+	n.namespace = namespace
+
+	return n, nil
+}
+
+// Child returns a copy of the [Naming] that creates the [NID]s in the namespace of the parent [NID],
+// e.g. "invoice_000034o1ibe7u02570ak9evj9s.line_000034o1ibe7u02570ak9evj9s". See [NID.Parent].
+// An empty parent returns the unscoped [Naming].
+func (n Naming) Child(parent NID) Naming {
+	n.initialized()
+
+	n.namespace = parent.String()
+
+	return n
+}
+
+// New creates a new [NID] at the current time.
+// With the [WithGenerator] it panics if the [Generator] fails, use the [Naming.Generate] to handle the error.
+func (n Naming) New() NID {
+	id, err := n.Generate()
+	if err != nil {
+		panic(err)
+	}
+
+	return id
 }
 
 // NewAt creates a new [NID] at the given time.
@@ -52,16 +190,20 @@ func (n Naming) NewAt(ts time.Time) NID {
 	n.initialized()
 
 	return NID{
-		name: n.name,
-		base: NewBaseAt(ts),
+		namespace: n.namespace,
+		name:      n.name,
+		sep:       n.sep(),
+		base:      n.Layout().NewBaseAt(ts),
 	}
 }
 
-// Is checks if the name of the [NID] matches namer's name.
+// Is checks if the name and the separator of the [NID] match namer's ones.
+// The legacy names set by the [WithAliases] match as well.
+// The scoped [Naming] also checks the namespace, while the unscoped one matches any namespace.
 func (n Naming) Is(id NID) bool {
 	n.initialized()
 
-	return n.name == id.Name()
+	return n.match(id.name) && n.sep() == id.sep && (n.namespace == "" || n.namespace == id.namespace)
 }
 
 // Apply create a new [NID] from the given [Base].
@@ -73,12 +215,93 @@ func (n Naming) Apply(base Base) NID {
 	}
 
 	return NID{
-		name: n.name,
-		base: base,
+		namespace: n.namespace,
+		name:      n.name,
+		sep:       n.sep(),
+		base:      base,
+	}
+}
+
+// Parse the [NID] from the string and check that it matches the [Naming].
+// Unlike the package [Parse], it honours the [Naming] separator and name rule.
+// An empty string results in an empty [NID].
+func (n Naming) Parse(str string) (NID, error) {
+	n.initialized()
+
+	if len(str) == 0 {
+		return NID{}, nil
+	}
+
+	namespace, name, text, err := n.split(str, encoding.EncodedLen(baseLen))
+	if err != nil {
+		return NID{}, err
+	}
+
+	base, err := ParseBase(text)
+	if err != nil {
+		return NID{}, err
+	} else if base.Empty() {
+		return NID{}, nil
+	}
+
+	return n.canonicalize(NID{namespace: namespace, name: name, sep: n.sep(), base: base}), nil
+}
+
+// split the "[<namespace>.]<name><sep><text>" string with the text of the given size,
+// checking that the name, the separator and the namespace match the [Naming].
+func (n Naming) split(str string, size int) (string, string, string, error) {
+	cut := len(str) - size - 1
+	if cut <= 0 || str[cut] != n.separator() {
+		return "", "", "", fmt.Errorf("%w: identifier %q doesn't match naming %q", ErrFailedParse, str, n.prefix())
+	}
+
+	namespace, name, scoped := splitNamespace(str[:cut])
+	if !n.match(name) || (n.namespace != "" && namespace != n.namespace) {
+		return "", "", "", fmt.Errorf("%w: identifier %q doesn't match naming %q", ErrFailedParse, str, n.prefix())
+	} else if scoped && !validateNamespace(namespace) {
+		return "", "", "", fmt.Errorf("%w: identifier namespace must be a dot-separated list of names: %q", ErrFailedParse, namespace)
+	}
+
+	return namespace, name, str[cut+1:], nil
+}
+
+// check returns an error if a non-empty [NID] doesn't match the [Naming].
+func (n Naming) check(id NID) error {
+	if id.Empty() || n.Is(id) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: identifier %q doesn't match naming %q", ErrFailedParse, id, n.prefix())
+}
+
+// prefix returns the "<namespace>.<name>" or just the name of the unscoped [Naming].
+func (n Naming) prefix() string {
+	if n.namespace == "" {
+		return n.name
+	}
+
+	return n.namespace + "." + n.name
+}
+
+// Storage returns the [Storage] format of the [Naming].
+func (n Naming) Storage() Storage {
+	if n.cfg == nil {
+		return StorageText
 	}
+
+	return n.cfg.storage
+}
+
+// Column returns a nullable database column of the [NID] stored in the [Naming] storage format.
+// It can be used both as a query argument and as a scan destination.
+func (n Naming) Column(id *NID) Column {
+	n.initialized()
+
+	return Column{naming: n, id: id}
 }
 
 // Update the name of the [NID] with the namer's name.
+// The scoped [Naming] also updates the namespace, while the unscoped one keeps it.
 func (n Naming) Update(id NID) NID {
 	n.initialized()
 
@@ -86,9 +309,16 @@ func (n Naming) Update(id NID) NID {
 		return NID{}
 	}
 
+	namespace := id.namespace
+	if n.namespace != "" {
+		namespace = n.namespace
+	}
+
 	return NID{
-		name: n.name,
-		base: id.base,
+		namespace: namespace,
+		name:      n.name,
+		sep:       n.sep(),
+		base:      id.base,
 	}
 }
 
@@ -118,3 +348,21 @@ func validateName(str string) bool {
 
 	return ok
 }
+
+// validateText checks that the name can be parsed with the package [Parse],
+// i.e. it's a snake_case name that may use any of the allowed separators, e.g. "user-profile".
+func validateText(str string) bool {
+	return validateName(strings.Map(normalizeSeparator, str))
+}
+
+// validateNamespace checks that every dot-separated segment of the namespace is a name.
+// Segments may use any of the allowed separators, so identifiers of any [Naming] can be used as a parent.
+func validateNamespace(str string) bool {
+	for _, seg := range strings.Split(str, ".") {
+		if !validateText(seg) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -124,6 +124,86 @@ func TestMustNaming(t *testing.T) {
 	}
 }
 
+func TestNaming_Equal(t *testing.T) {
+	rule := nid.MustNaming("user", nid.WithNameRule(nid.SnakeCase))
+
+	tt := []struct {
+		name      string
+		a, b      nid.Naming
+		want      bool
+		wantEqual bool
+	}{
+		{
+			name:      "default",
+			a:         nid.MustNaming("user"),
+			b:         nid.MustNaming("user"),
+			want:      true,
+			wantEqual: true,
+		},
+		{
+			name:      "default_options",
+			a:         nid.MustNaming("user"),
+			b:         nid.MustNaming("user", nid.WithStorage(nid.StorageText), nid.WithSeparator('_')),
+			want:      true,
+			wantEqual: true,
+		},
+		{
+			name:      "same_options",
+			a:         nid.MustNaming("user", nid.WithStorage(nid.StorageBinary)),
+			b:         nid.MustNaming("user", nid.WithStorage(nid.StorageBinary)),
+			want:      false,
+			wantEqual: true,
+		},
+		{
+			name:      "other_options",
+			a:         nid.MustNaming("user", nid.WithStorage(nid.StorageBinary)),
+			b:         nid.MustNaming("user", nid.WithStorage(nid.StorageUUID)),
+			want:      false,
+			wantEqual: false,
+		},
+		{
+			name:      "other_name",
+			a:         nid.MustNaming("user"),
+			b:         nid.MustNaming("book"),
+			want:      false,
+			wantEqual: false,
+		},
+		{
+			name:      "other_namespace",
+			a:         nid.MustNaming("user"),
+			b:         mustScope(nid.MustNaming("user"), "acme"),
+			want:      false,
+			wantEqual: false,
+		},
+		{
+			name:      "rule_copy",
+			a:         rule,
+			b:         rule,
+			want:      true,
+			wantEqual: true,
+		},
+		{
+			name:      "rule",
+			a:         rule,
+			b:         nid.MustNaming("user", nid.WithNameRule(nid.SnakeCase)),
+			want:      false,
+			wantEqual: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.a == tc.b; got != tc.want {
+				t.Errorf("Naming == Naming = %v; want = %v", got, tc.want)
+			}
+
+			if got := tc.a.Equal(tc.b); got != tc.wantEqual {
+				t.Errorf("Naming.Equal() = %v; want = %v", got, tc.wantEqual)
+			}
+		})
+	}
+}
+
 func TestNaming_New(t *testing.T) {
 	tt := []struct {
 		name      string
@@ -236,6 +316,30 @@ func TestNaming_Is(t *testing.T) {
 			id:   nid.NID{},
 			want: false,
 		},
+		{
+			name: "unscoped any namespace",
+			idn:  nid.MustNaming("book"),
+			id:   nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+			want: true,
+		},
+		{
+			name: "scoped same namespace",
+			idn:  mustScope(nid.MustNaming("book"), "acme"),
+			id:   nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+			want: true,
+		},
+		{
+			name: "scoped different namespace",
+			idn:  mustScope(nid.MustNaming("book"), "acme"),
+			id:   nid.MustParse("other.book_000034o1ibe7u02570ak9evj9s"),
+			want: false,
+		},
+		{
+			name: "scoped no namespace",
+			idn:  mustScope(nid.MustNaming("book"), "acme"),
+			id:   nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+			want: false,
+		},
 	}
 
 	for _, tc := range tt {
@@ -328,6 +432,18 @@ func TestNaming_Update(t *testing.T) {
 			id:   nid.MustParse("author_000034o1ibe7u02570ak9evj9s"),
 			want: nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
 		},
+		{
+			name: "unscoped keeps namespace",
+			idn:  nid.MustNaming("book"),
+			id:   nid.MustParse("acme.author_000034o1ibe7u02570ak9evj9s"),
+			want: nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+		},
+		{
+			name: "scoped updates namespace",
+			idn:  mustScope(nid.MustNaming("book"), "other"),
+			id:   nid.MustParse("acme.author_000034o1ibe7u02570ak9evj9s"),
+			want: nid.MustParse("other.book_000034o1ibe7u02570ak9evj9s"),
+		},
 	}
 
 	for _, tc := range tt {
@@ -345,3 +461,157 @@ func TestNaming_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestNaming_Parse(t *testing.T) {
+	tt := []struct {
+		name      string
+		idn       nid.Naming
+		str       string
+		want      nid.NID
+		wantErr   bool
+		wantPanic bool
+	}{
+		{
+			name:      "not initialized",
+			idn:       nid.Naming{},
+			wantPanic: true,
+		},
+		{
+			name: "empty",
+			idn:  nid.MustNaming("book"),
+			str:  "",
+			want: nid.NID{},
+		},
+		{
+			name: "same name",
+			idn:  nid.MustNaming("book"),
+			str:  "book_000034o1ibe7u02570ak9evj9s",
+			want: nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+		},
+		{
+			name:    "different name",
+			idn:     nid.MustNaming("book"),
+			str:     "author_000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			idn:     nid.MustNaming("book"),
+			str:     "book_!00034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if tc.wantPanic == (r == nil) {
+					t.Errorf("Naming.Parse(); panic = %v; wantPanic = %v", r, tc.wantPanic)
+				}
+			}()
+
+			got, err := tc.idn.Parse(tc.str)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("Naming.Parse(str = %s) err = %v, wantErr %v", tc.str, err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("Naming.Parse(str = %s) = %v, want %v", tc.str, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNaming_Scope(t *testing.T) {
+	tt := []struct {
+		name      string
+		idn       nid.Naming
+		namespace string
+		wantNew   string
+		wantErr   bool
+		wantPanic bool
+	}{
+		{
+			name:      "not initialized",
+			idn:       nid.Naming{},
+			wantPanic: true,
+		},
+		{
+			name:      "empty",
+			idn:       mustScope(nid.MustNaming("book"), "acme"),
+			namespace: "",
+			wantNew:   "book_",
+		},
+		{
+			name:      "single",
+			idn:       nid.MustNaming("book"),
+			namespace: "acme",
+			wantNew:   "acme.book_",
+		},
+		{
+			name:      "nested",
+			idn:       nid.MustNaming("book"),
+			namespace: "acme.eu_west",
+			wantNew:   "acme.eu_west.book_",
+		},
+		{
+			name:      "invalid",
+			idn:       nid.MustNaming("book"),
+			namespace: "Acme",
+			wantErr:   true,
+		},
+		{
+			name:      "empty segment",
+			idn:       nid.MustNaming("book"),
+			namespace: "acme.",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if tc.wantPanic == (r == nil) {
+					t.Errorf("Naming.Scope(); panic = %v; wantPanic = %v", r, tc.wantPanic)
+				}
+			}()
+
+			idn, err := tc.idn.Scope(tc.namespace)
+			if tc.wantErr == (err == nil) {
+				t.Fatalf("Naming.Scope(%s) err = %v, wantErr %v", tc.namespace, err, tc.wantErr)
+			}
+
+			if tc.wantErr {
+				return
+			}
+
+			if got := idn.New().String(); got[:len(got)-26] != tc.wantNew {
+				t.Errorf("Naming.Scope(%s).New() = %s, want prefix %s", tc.namespace, got, tc.wantNew)
+			}
+
+			if idn.Namespace() != tc.namespace || idn.Name() != "book" {
+				t.Errorf("Naming.Scope(%s) = %s, %s", tc.namespace, idn.Namespace(), idn.Name())
+			}
+		})
+	}
+}
+
+func TestNaming_Child(t *testing.T) {
+	parent := nid.MustParse("acme.invoice_000034o1ibe7u02570ak9evj9s")
+	lineIDN := nid.MustNaming("line").Child(parent)
+
+	line := lineIDN.New()
+	if got := line.Parent(); got != parent {
+		t.Errorf("Naming.Child().New().Parent() = %s; want = %s", got, parent)
+	}
+
+	if !lineIDN.Is(line) || lineIDN.Is(nid.MustNaming("line").New()) {
+		t.Errorf("Naming.Child().Is() doesn't check parent")
+	}
+
+	if got := nid.MustNaming("line").Child(nid.NID{}).Namespace(); got != "" {
+		t.Errorf("Naming.Child(empty).Namespace() = %s; want empty", got)
+	}
+}
@@ -15,9 +15,12 @@ import (
 
 // NID is a named identifier.
 // It consists of the name and the base identifier that is sortable and unique.
+// It may have an optional namespace, e.g. a tenant or a parent identifier.
 type NID struct {
-	name string
-	base Base
+	namespace string
+	name      string
+	sep       byte
+	base      Base
 }
 
 // MustParse is a helper to parse named [NID]. It panics if given string is invalid.
@@ -42,31 +45,76 @@ func (id NID) Name() string {
 	return id.name
 }
 
+// Namespace returns the namespace of the ID or an empty string if the ID has no namespace.
+func (id NID) Namespace() string {
+	return id.namespace
+}
+
+// Parent returns the parent ID if the last segment of the namespace is an ID, e.g.
+// "invoice_000034o1ibe7u02570ak9evj9s" for "invoice_000034o1ibe7u02570ak9evj9s.line_000034o1ibe7u02570ak9evj9s".
+// Otherwise, it returns an empty ID.
+func (id NID) Parent() NID {
+	var parent NID
+
+	if err := parent.UnmarshalText([]byte(id.namespace)); err != nil {
+		return NID{}
+	}
+
+	return parent
+}
+
 // Base returns the base identifier.
 func (id NID) Base() Base {
 	return id.base
 }
 
 // String returns the string representation of the ID.
-// The format is "<name>_<id>".
+// The format is "<name>_<id>" or "<namespace>.<name>_<id>" if the ID has a namespace.
 func (id NID) String() string {
-	bytes, _ := id.MarshalText()
+	if id.Empty() {
+		return ""
+	}
+
+	str := id.name + string(id.separator()) + id.base.String()
+	if id.namespace != "" {
+		str = id.namespace + "." + str
+	}
+
+	return str
+}
+
+// separator returns the separator between the name and the base.
+func (id NID) separator() byte {
+	if id.sep == 0 {
+		return defaultSep
+	}
 
-	return string(bytes)
+	return id.sep
 }
 
 // MarshalText returns the text representation of the ID.
+// It returns the [ErrInvalidName] error if the name was allowed by a custom [NameRule],
+// but can't be parsed back with the package [Parse], see [WithNameRule].
 func (id NID) MarshalText() ([]byte, error) {
-	if len(id.name) == 0 || id.base.Empty() {
-		return []byte{}, nil
+	if err := id.parsable(); err != nil {
+		return nil, err
 	}
 
-	str := strings.Join([]string{id.name, id.base.String()}, "_")
+	return []byte(id.String()), nil
+}
 
-	return []byte(str), nil
+// parsable returns an error if the text of a non-empty ID can't be parsed back with the package [Parse].
+func (id NID) parsable() error {
+	if id.Empty() || (validateText(id.name) && (id.namespace == "" || validateNamespace(id.namespace))) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: identifier %q can be parsed with its naming only", ErrInvalidName, id.String())
 }
 
 // UnmarshalText parses the ID from the text.
+// The name may use any of the allowed separators, e.g. "user_profile" or "user-profile",
+// and so may the separator between the name and the base, see [WithSeparator].
 func (id *NID) UnmarshalText(data []byte) error {
 	if len(data) == 0 {
 		*id = NID{}
@@ -76,27 +124,38 @@ func (id *NID) UnmarshalText(data []byte) error {
 
 	str := string(data)
 
-	cut := strings.LastIndex(str, "_")
-	if cut <= 0 || cut == len(str)-1 {
+	cut := len(str) - encoding.EncodedLen(baseLen) - 1
+	if cut <= 0 || strings.IndexByte(separators, str[cut]) < 0 {
 		return fmt.Errorf("%w: invalid named identifier: %q", ErrFailedParse, str)
 	}
 
-	name := str[:cut]
-	if !validateName(name) {
-		return fmt.Errorf("%w: identifier name must be a non-empty snake_case string: %q", ErrFailedParse, name)
+	namespace, name, scoped := splitNamespace(str[:cut])
+	if !validateText(name) {
+		return fmt.Errorf("%w: identifier name must be a non-empty snake_case or kebab-case string: %q", ErrFailedParse, name)
+	} else if scoped && !validateNamespace(namespace) {
+		return fmt.Errorf("%w: identifier namespace must be a dot-separated list of snake_case strings: %q", ErrFailedParse, namespace)
 	}
 
-	err := id.base.UnmarshalText([]byte(str[cut+1:]))
+	var base Base
+
+	err := base.UnmarshalText([]byte(str[cut+1:]))
 	if err != nil {
 		return err
 	}
 
-	if id.base.Empty() {
-		id.name = ""
-	} else {
-		id.name = name
+	if base.Empty() {
+		*id = NID{}
+
+		return nil
 	}
 
+	sep := str[cut]
+	if sep == defaultSep {
+		sep = 0
+	}
+
+	*id = NID{namespace: namespace, name: name, sep: sep, base: base}
+
 	return nil
 }
 
@@ -104,6 +163,8 @@ func (id *NID) UnmarshalText(data []byte) error {
 func (id NID) MarshalJSON() ([]byte, error) {
 	if id.Empty() {
 		return []byte("null"), nil
+	} else if err := id.parsable(); err != nil {
+		return nil, err
 	}
 
 	return json.Marshal(id.String())
@@ -136,6 +197,8 @@ func (id NID) Empty() bool {
 func (id NID) Value() (driver.Value, error) {
 	if id.Empty() {
 		return nil, nil
+	} else if err := id.parsable(); err != nil {
+		return nil, err
 	}
 
 	return id.String(), nil
@@ -144,8 +207,7 @@ func (id NID) Value() (driver.Value, error) {
 // Scan the value into the ID.
 func (id *NID) Scan(src any) error {
 	if src == nil {
-		id.base = Base{}
-		id.name = ""
+		*id = NID{}
 
 		return nil
 	}
@@ -159,3 +221,14 @@ func (id *NID) Scan(src any) error {
 		return fmt.Errorf("%w: invalid scan source: %T", ErrFailedParse, src)
 	}
 }
+
+// splitNamespace splits the "<namespace>.<name>" string into the namespace and the name.
+// It reports false if the string has no namespace separator.
+func splitNamespace(str string) (string, string, bool) {
+	dot := strings.LastIndex(str, ".")
+	if dot < 0 {
+		return "", str, false
+	}
+
+	return str[:dot], str[dot+1:], true
+}
@@ -312,6 +312,36 @@ func TestNIDUnmarshalText(t *testing.T) {
 			src:     []byte("book_!00034o1ibe7u02570ak9evj9s"),
 			wantErr: true,
 		},
+		{
+			name: "namespace",
+			src:  []byte("acme.eu.book_000034o1ibe7u02570ak9evj9s"),
+			want: mustScope(nid.MustNaming("book"), "acme.eu").Apply(
+				nid.Base{
+					0x00, 0x00, 0x01, 0x93, 0x01, 0x92, 0xdc, 0x7f,
+					0x00, 0x45, 0x38, 0x15, 0x44, 0xbb, 0xf3, 0x4f,
+				},
+			),
+		},
+		{
+			name:    "empty_namespace",
+			src:     []byte(".book_000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
+		{
+			name:    "empty_namespace_segment",
+			src:     []byte("acme..book_000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid_namespace",
+			src:     []byte("Acme.book_000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
+		{
+			name:    "empty_name_in_namespace",
+			src:     []byte("acme._000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tt {
@@ -568,3 +598,70 @@ func TestNIDScan(t *testing.T) {
 		})
 	}
 }
+
+func TestNIDNamespace(t *testing.T) {
+	invoice := nid.MustParse("acme.invoice_000034o1ibe7u02570ak9evj9s")
+	line := nid.MustNaming("line").Child(invoice).Apply(nid.MustParseBase("000034o1ibe7u02570ak9evjfs"))
+
+	tt := []struct {
+		name          string
+		id            nid.NID
+		wantString    string
+		wantNamespace string
+		wantParent    nid.NID
+	}{
+		{
+			name:       "plain",
+			id:         nid.MustParse("invoice_000034o1ibe7u02570ak9evj9s"),
+			wantString: "invoice_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:          "tenant",
+			id:            invoice,
+			wantString:    "acme.invoice_000034o1ibe7u02570ak9evj9s",
+			wantNamespace: "acme",
+		},
+		{
+			name:          "child",
+			id:            line,
+			wantString:    "acme.invoice_000034o1ibe7u02570ak9evj9s.line_000034o1ibe7u02570ak9evjfs",
+			wantNamespace: "acme.invoice_000034o1ibe7u02570ak9evj9s",
+			wantParent:    invoice,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.id.String(); got != tc.wantString {
+				t.Errorf("NID.String() = %s; want = %s", got, tc.wantString)
+			}
+
+			if got := tc.id.Namespace(); got != tc.wantNamespace {
+				t.Errorf("NID.Namespace() = %s; want = %s", got, tc.wantNamespace)
+			}
+
+			if got := tc.id.Parent(); got != tc.wantParent {
+				t.Errorf("NID.Parent() = %s; want = %s", got, tc.wantParent)
+			}
+
+			if got := nid.MustParse(tc.wantString); got != tc.id {
+				t.Errorf("MustParse(%s) = %#v; want = %#v", tc.wantString, got, tc.id)
+			}
+
+			data, err := tc.id.MarshalJSON()
+			if err != nil {
+				t.Fatalf("NID.MarshalJSON() unexpected err = %v", err)
+			}
+
+			var got nid.NID
+			if err := got.UnmarshalJSON(data); err != nil || got != tc.id {
+				t.Errorf("NID.UnmarshalJSON(%s) = %s, %v; want = %s", data, got, err, tc.id)
+			}
+
+			value, err := tc.id.Value()
+			if err != nil || value != tc.wantString {
+				t.Errorf("NID.Value() = %v, %v; want = %s", value, err, tc.wantString)
+			}
+		})
+	}
+}
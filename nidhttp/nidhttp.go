@@ -0,0 +1,147 @@
+// Package nidhttp implements helpers to read the named identifiers from the net/http requests.
+package nidhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.wamod.dev/nid"
+)
+
+// Error codes of the [Error].
+const (
+	CodeMissing = "missing_identifier"
+	CodeInvalid = "invalid_identifier"
+)
+
+// ErrMissing is returned when the request doesn't contain the identifier.
+var ErrMissing = fmt.Errorf("nidhttp: missing identifier")
+
+// Error is a failure to read the identifier from the request. It's written as the JSON body of the 400 response:
+//
+//	{"error": {"code": "invalid_identifier", "message": "...", "param": "id", "in": "path"}}
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"param"`
+	In      string `json:"in"`
+
+	err error
+}
+
+// Error returns the message of the [Error].
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s parameter %q: %s", e.In, e.Param, e.Message)
+}
+
+// Unwrap returns the underlying error, e.g. the [nid.ErrFailedParse] or the [ErrMissing].
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// PathValue parses the [nid.NID] of the [nid.Naming] from the path value of the request, see [http.Request.PathValue].
+// It returns the [Error] if the value is missing or invalid.
+func PathValue(r *http.Request, name string, n nid.Naming) (nid.NID, error) {
+	return parse(r.PathValue(name), name, "path", n)
+}
+
+// Query parses the [nid.NID] of the [nid.Naming] from the query parameter of the request.
+// It returns the [Error] if the parameter is missing or invalid.
+func Query(r *http.Request, key string, n nid.Naming) (nid.NID, error) {
+	return parse(r.URL.Query().Get(key), key, "query", n)
+}
+
+// PathID parses the [nid.NID] like the [PathValue] and writes the 400 response on failure.
+// It returns false if the handler should stop:
+//
+//	id, ok := nidhttp.PathID(w, r, "id", BookIDN)
+//	if !ok {
+//		return
+//	}
+func PathID(w http.ResponseWriter, r *http.Request, name string, n nid.Naming) (nid.NID, bool) {
+	id, err := PathValue(r, name, n)
+	if err != nil {
+		WriteError(w, err)
+
+		return nid.NID{}, false
+	}
+
+	return id, true
+}
+
+// QueryID parses the [nid.NID] like the [Query] and writes the 400 response on failure.
+// It returns false if the handler should stop.
+func QueryID(w http.ResponseWriter, r *http.Request, key string, n nid.Naming) (nid.NID, bool) {
+	id, err := Query(r, key, n)
+	if err != nil {
+		WriteError(w, err)
+
+		return nid.NID{}, false
+	}
+
+	return id, true
+}
+
+// WriteError writes the 400 response with the JSON body of the [Error].
+// Other errors are written as the [CodeInvalid] errors with a generic message, so their text isn't exposed to the client.
+func WriteError(w http.ResponseWriter, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = &Error{Code: CodeInvalid, Message: "invalid identifier"}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusBadRequest)
+
+	_ = json.NewEncoder(w).Encode(struct {
+		Error *Error `json:"error"`
+	}{Error: e})
+}
+
+// Require returns the middleware that parses the [nid.NID] of the [nid.Naming] from the path value,
+// writes the 400 response on failure, or stores the [nid.NID] in the request context with the [nid.Naming.NewContext].
+func Require(name string, n nid.Naming) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, ok := PathID(w, r, name, n)
+			if !ok {
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(n.NewContext(r.Context(), id)))
+		})
+	}
+}
+
+// FromContext returns the [nid.NID] of the [nid.Naming] stored by the [Require], the [Middleware]
+// or the [nid.Naming.NewContext]. It's the same as the [nid.Naming.FromContext].
+func FromContext(ctx context.Context, n nid.Naming) (nid.NID, bool) {
+	return n.FromContext(ctx)
+}
+
+func parse(str, param, in string, n nid.Naming) (nid.NID, error) {
+	if str == "" {
+		return nid.NID{}, &Error{Code: CodeMissing, Message: "identifier is required", Param: param, In: in, err: ErrMissing}
+	}
+
+	id, err := n.Parse(str)
+	if err == nil && id.Empty() {
+		err = fmt.Errorf("%w: empty identifier", nid.ErrFailedParse)
+	}
+
+	if err != nil {
+		return nid.NID{}, &Error{
+			Code:    CodeInvalid,
+			Message: fmt.Sprintf("must be a %q identifier", n.Name()),
+			Param:   param,
+			In:      in,
+			err:     err,
+		}
+	}
+
+	return id, nil
+}
@@ -0,0 +1,184 @@
+package nidhttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/nid"
+	"go.wamod.dev/nid/nidhttp"
+)
+
+var bookIDN = nid.MustNaming("book") //nolint:gochecknoglobals
+
+type errorBody struct {
+	Error nidhttp.Error `json:"error"`
+}
+
+func TestPathID(t *testing.T) {
+	tt := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantErr  string
+		wantID   string
+	}{
+		{
+			name:     "valid",
+			path:     "/books/book_000034o1ibe7u02570ak9evj9s",
+			wantCode: http.StatusOK,
+			wantID:   "book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:     "other_naming",
+			path:     "/books/author_000034o1ibe7u02570ak9evj9s",
+			wantCode: http.StatusBadRequest,
+			wantErr:  nidhttp.CodeInvalid,
+		},
+		{
+			name:     "malformed",
+			path:     "/books/book_123",
+			wantCode: http.StatusBadRequest,
+			wantErr:  nidhttp.CodeInvalid,
+		},
+		{
+			name:     "zero",
+			path:     "/books/book_00000000000000000000000000",
+			wantCode: http.StatusBadRequest,
+			wantErr:  nidhttp.CodeInvalid,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := nidhttp.PathID(w, r, "id", bookIDN)
+		if !ok {
+			return
+		}
+
+		_, _ = w.Write([]byte(id.String()))
+	})
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if rec.Code != tc.wantCode {
+				t.Fatalf("status = %d; want = %d", rec.Code, tc.wantCode)
+			}
+
+			if tc.wantErr == "" {
+				if got := rec.Body.String(); got != tc.wantID {
+					t.Errorf("body = %q; want = %q", got, tc.wantID)
+				}
+
+				return
+			}
+
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body = %q; unexpected err = %v", rec.Body.String(), err)
+			}
+
+			if body.Error.Code != tc.wantErr || body.Error.Param != "id" || body.Error.In != "path" || body.Error.Message == "" {
+				t.Errorf("body = %+v; want code %q for path parameter \"id\"", body.Error, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tt := []struct {
+		name    string
+		target  string
+		wantErr error
+	}{
+		{
+			name:   "valid",
+			target: "/books?author=book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:    "missing",
+			target:  "/books",
+			wantErr: nidhttp.ErrMissing,
+		},
+		{
+			name:    "invalid",
+			target:  "/books?author=book_",
+			wantErr: nid.ErrFailedParse,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.target, nil)
+
+			_, err := nidhttp.Query(r, "author", bookIDN)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Fatalf("Query() err = %v; want = %v", err, tc.wantErr)
+			}
+
+			var e *nidhttp.Error
+			if err != nil && (!errors.As(err, &e) || e.In != "query" || e.Param != "author") {
+				t.Errorf("Query() err = %#v; want query parameter \"author\"", err)
+			}
+
+			rec := httptest.NewRecorder()
+			if _, ok := nidhttp.QueryID(rec, r, "author", bookIDN); ok != (err == nil) {
+				t.Errorf("QueryID() ok = %v; want = %v", ok, err == nil)
+			}
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /books/{id}", nidhttp.Require("id", bookIDN)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := nidhttp.FromContext(r.Context(), bookIDN)
+		if !ok {
+			t.Errorf("FromContext() ok = false; want = true")
+		}
+
+		if same, _ := bookIDN.FromContext(r.Context()); same != id {
+			t.Errorf("Naming.FromContext() = %v; want = %v", same, id)
+		}
+
+		_, _ = w.Write([]byte(id.String()))
+	})))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books/book_000034o1ibe7u02570ak9evj9s", nil))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "book_000034o1ibe7u02570ak9evj9s" {
+		t.Errorf("response = %d %q; want 200 with the identifier", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books/nope", nil))
+
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("response = %d %q; want 400 JSON", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	if _, ok := nidhttp.FromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context(), bookIDN); ok {
+		t.Errorf("FromContext() ok = true; want = false")
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	nidhttp.WriteError(rec, errors.New("pq: connection refused"))
+
+	var body errorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error.Code != nidhttp.CodeInvalid || body.Error.Message != "invalid identifier" {
+		t.Errorf("WriteError() body = %q; want invalid identifier error", rec.Body.String())
+	}
+
+	if strings.Contains(rec.Body.String(), "pq:") {
+		t.Errorf("WriteError() body = %q; want no internal error text", rec.Body.String())
+	}
+}
@@ -0,0 +1,56 @@
+package nidhttp
+
+import (
+	"net/http"
+
+	"go.wamod.dev/nid"
+)
+
+// HeaderRequestID is the common header of the request ID.
+const HeaderRequestID = "X-Request-Id"
+
+// Middleware returns the middleware that reads the [nid.NID] of the [nid.Naming] from the request header,
+// or mints a new one if the header is missing or invalid. The [nid.NID] is stored in the request context,
+// see [nid.Naming.FromContext], and echoed in the response header.
+func Middleware(header string, n nid.Naming) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := n.Parse(r.Header.Get(header))
+			if err != nil || id.Empty() {
+				id = n.New()
+			}
+
+			w.Header().Set(header, id.String())
+
+			next.ServeHTTP(w, r.WithContext(n.NewContext(r.Context(), id)))
+		})
+	}
+}
+
+// Transport propagates the [nid.NID] of the [nid.Naming] from the request context to the outgoing request header.
+// The requests without the [nid.NID] in the context and with the header already set are sent as is.
+//
+//	client := &http.Client{Transport: &nidhttp.Transport{Header: nidhttp.HeaderRequestID, Naming: RequestIDN}}
+type Transport struct {
+	// Base is the underlying [http.RoundTripper]. If nil, the [http.DefaultTransport] is used.
+	Base http.RoundTripper
+	// Header is the name of the header.
+	Header string
+	// Naming of the propagated [nid.NID]. It must be initialized.
+	Naming nid.Naming
+}
+
+// RoundTrip sets the header and sends the request with the [Transport.Base].
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if id, ok := t.Naming.FromContext(r.Context()); ok && r.Header.Get(t.Header) == "" {
+		r = r.Clone(r.Context())
+		r.Header.Set(t.Header, id.String())
+	}
+
+	return base.RoundTrip(r)
+}
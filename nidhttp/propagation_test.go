@@ -0,0 +1,139 @@
+package nidhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.wamod.dev/nid"
+	"go.wamod.dev/nid/nidhttp"
+)
+
+func TestMiddleware(t *testing.T) {
+	requestIDN := nid.MustNaming("req")
+	existing := requestIDN.New()
+
+	tt := []struct {
+		name     string
+		header   string
+		wantSame bool
+	}{
+		{
+			name:     "incoming",
+			header:   existing.String(),
+			wantSame: true,
+		},
+		{
+			name: "missing",
+		},
+		{
+			name:   "invalid",
+			header: "book_000034o1ibe7u02570ak9evj9s",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got nid.NID
+
+			handler := nidhttp.Middleware(nidhttp.HeaderRequestID, requestIDN)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got, _ = requestIDN.FromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				r.Header.Set(nidhttp.HeaderRequestID, tc.header)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+
+			if !requestIDN.Is(got) || got.Empty() {
+				t.Fatalf("FromContext() = %v; want a %q identifier", got, requestIDN.Name())
+			}
+
+			if (got == existing) != tc.wantSame {
+				t.Errorf("FromContext() = %v; want same as incoming = %v", got, tc.wantSame)
+			}
+
+			if header := rec.Header().Get(nidhttp.HeaderRequestID); header != got.String() {
+				t.Errorf("response header = %q; want = %q", header, got)
+			}
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestTransport(t *testing.T) {
+	requestIDN := nid.MustNaming("req")
+	id := requestIDN.New()
+
+	tt := []struct {
+		name   string
+		id     nid.NID
+		header string
+		want   string
+	}{
+		{
+			name: "propagated",
+			id:   id,
+			want: id.String(),
+		},
+		{
+			name: "no_context",
+		},
+		{
+			name:   "header_set",
+			id:     id,
+			header: "custom",
+			want:   "custom",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+
+			client := &http.Client{Transport: &nidhttp.Transport{
+				Base: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					got = r.Header.Get(nidhttp.HeaderRequestID)
+
+					return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody, Request: r}, nil
+				}),
+				Header: nidhttp.HeaderRequestID,
+				Naming: requestIDN,
+			}}
+
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			r.RequestURI = ""
+
+			if !tc.id.Empty() {
+				r = r.WithContext(requestIDN.NewContext(r.Context(), tc.id))
+			}
+
+			if tc.header != "" {
+				r.Header.Set(nidhttp.HeaderRequestID, tc.header)
+			}
+
+			resp, err := client.Do(r)
+			if err != nil {
+				t.Fatalf("Client.Do() unexpected err = %v", err)
+			}
+
+			_ = resp.Body.Close()
+
+			if got != tc.want {
+				t.Errorf("outgoing header = %q; want = %q", got, tc.want)
+			}
+
+			if tc.header == "" && r.Header.Get(nidhttp.HeaderRequestID) != "" {
+				t.Errorf("Transport modified the original request")
+			}
+		})
+	}
+}
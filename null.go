@@ -0,0 +1,158 @@
+package nid
+
+import (
+	"bytes"
+	"database/sql/driver"
+)
+
+// NullNID represents a [NID] that may be null.
+// It adds the explicit Valid flag to the [NID], similar to the [sql.Null],
+// and implements the [sql.Scanner] and [driver.Valuer] interfaces.
+//
+// When decoded from JSON, Present reports whether the field was present at all,
+// which allows to distinguish a missing field, null and a value in PATCH requests.
+// An empty identifier, e.g. JSON "" or an empty string scanned from the database,
+// is still decoded as null, the same as for the [NID].
+type NullNID struct {
+	NID     NID
+	Valid   bool // Valid is true if NID is not NULL.
+	Present bool // Present is true if the value was present in the decoded JSON.
+}
+
+// Value returns the driver value.
+func (n NullNID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.NID.Value()
+}
+
+// Scan the value into the [NullNID].
+func (n *NullNID) Scan(src any) error {
+	if src == nil {
+		n.NID, n.Valid = NID{}, false
+
+		return nil
+	}
+
+	err := n.NID.Scan(src)
+	if err != nil {
+		n.NID, n.Valid = NID{}, false
+
+		return err
+	}
+
+	n.Valid = !n.NID.Empty()
+
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the [NullNID].
+func (n NullNID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.NID.MarshalJSON()
+}
+
+// UnmarshalJSON parses the [NullNID] from the JSON.
+func (n *NullNID) UnmarshalJSON(src []byte) error {
+	n.Present = true
+
+	if bytes.Equal(src, []byte("null")) {
+		n.NID, n.Valid = NID{}, false
+
+		return nil
+	}
+
+	err := n.NID.UnmarshalJSON(src)
+	if err != nil {
+		n.NID, n.Valid = NID{}, false
+
+		return err
+	}
+
+	n.Valid = !n.NID.Empty()
+
+	return nil
+}
+
+// IsZero returns true if the [NullNID] is neither present nor valid.
+func (n NullNID) IsZero() bool {
+	return !n.Valid && !n.Present
+}
+
+// NullBase represents a [Base] that may be null.
+// It follows the same semantics as the [NullNID].
+type NullBase struct {
+	Base    Base
+	Valid   bool // Valid is true if Base is not NULL.
+	Present bool // Present is true if the value was present in the decoded JSON.
+}
+
+// Value returns the driver value.
+func (n NullBase) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Base.Value()
+}
+
+// Scan the value into the [NullBase].
+func (n *NullBase) Scan(src any) error {
+	if src == nil {
+		n.Base, n.Valid = Base{}, false
+
+		return nil
+	}
+
+	err := n.Base.Scan(src)
+	if err != nil {
+		n.Base, n.Valid = Base{}, false
+
+		return err
+	}
+
+	n.Valid = !n.Base.Empty()
+
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of the [NullBase].
+func (n NullBase) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.Base.MarshalJSON()
+}
+
+// UnmarshalJSON parses the [NullBase] from the JSON.
+func (n *NullBase) UnmarshalJSON(src []byte) error {
+	n.Present = true
+
+	if bytes.Equal(src, []byte("null")) {
+		n.Base, n.Valid = Base{}, false
+
+		return nil
+	}
+
+	err := n.Base.UnmarshalJSON(src)
+	if err != nil {
+		n.Base, n.Valid = Base{}, false
+
+		return err
+	}
+
+	n.Valid = !n.Base.Empty()
+
+	return nil
+}
+
+// IsZero returns true if the [NullBase] is neither present nor valid.
+func (n NullBase) IsZero() bool {
+	return !n.Valid && !n.Present
+}
@@ -0,0 +1,333 @@
+package nid_test
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNullNIDUnmarshalJSON(t *testing.T) {
+	type patch struct {
+		ID nid.NullNID `json:"id"`
+	}
+
+	tt := []struct {
+		name    string
+		src     string
+		want    nid.NullNID
+		wantErr bool
+	}{
+		{
+			name: "missing",
+			src:  `{}`,
+			want: nid.NullNID{},
+		},
+		{
+			name: "null",
+			src:  `{"id":null}`,
+			want: nid.NullNID{Present: true},
+		},
+		{
+			name: "empty_string",
+			src:  `{"id":""}`,
+			want: nid.NullNID{Present: true},
+		},
+		{
+			name: "value",
+			src:  `{"id":"book_000034o1ibe7u02570ak9evj9s"}`,
+			want: nid.NullNID{
+				NID:     nid.MustParse("book_000034o1ibe7u02570ak9evj9s"),
+				Valid:   true,
+				Present: true,
+			},
+		},
+		{
+			name:    "invalid",
+			src:     `{"id":"Book_000034o1ibe7u02570ak9evj9s"}`,
+			want:    nid.NullNID{Present: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got patch
+
+			err := json.Unmarshal([]byte(tc.src), &got)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("NullNID.UnmarshalJSON() = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if got.ID != tc.want {
+				t.Errorf("NullNID.UnmarshalJSON() = %+v; want = %+v", got.ID, tc.want)
+			}
+
+			if zero := !tc.want.Present; got.ID.IsZero() != zero {
+				t.Errorf("NullNID.IsZero() = %v; want = %v", got.ID.IsZero(), zero)
+			}
+		})
+	}
+}
+
+func TestNullNIDMarshalJSON(t *testing.T) {
+	tt := []struct {
+		name string
+		id   nid.NullNID
+		want []byte
+	}{
+		{
+			name: "invalid",
+			id:   nid.NullNID{NID: nid.MustParse("book_000034o1ibe7u02570ak9evj9s")},
+			want: []byte("null"),
+		},
+		{
+			name: "valid",
+			id:   nid.NullNID{NID: nid.MustParse("book_000034o1ibe7u02570ak9evj9s"), Valid: true},
+			want: []byte("\"book_000034o1ibe7u02570ak9evj9s\""),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.id.MarshalJSON()
+			if err != nil {
+				t.Errorf("NullNID.MarshalJSON() unexpected err = %v", err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("NullNID.MarshalJSON() = %s; want = %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNullNIDScan(t *testing.T) {
+	tt := []struct {
+		name      string
+		src       any
+		want      nid.NullNID
+		wantValue driver.Value
+		wantErr   bool
+	}{
+		{
+			name:      "nil",
+			src:       nil,
+			want:      nid.NullNID{},
+			wantValue: nil,
+		},
+		{
+			name:      "string",
+			src:       "book_000034o1ibe7u02570ak9evj9s",
+			want:      nid.NullNID{NID: nid.MustParse("book_000034o1ibe7u02570ak9evj9s"), Valid: true},
+			wantValue: "book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:      "empty_string",
+			src:       "",
+			want:      nid.NullNID{},
+			wantValue: nil,
+		},
+		{
+			name:      "empty_bytes",
+			src:       []byte{},
+			want:      nid.NullNID{},
+			wantValue: nil,
+		},
+		{
+			name:    "int64",
+			src:     int64(123),
+			want:    nid.NullNID{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := nid.NullNID{NID: nid.MustParse("author_000034o1ibe7u02570ak9evj9s"), Valid: true}
+
+			err := got.Scan(tc.src)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("NullNID.Scan() = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("NullNID.Scan() = %+v; want = %+v", got, tc.want)
+			}
+
+			value, err := got.Value()
+			if err != nil {
+				t.Errorf("NullNID.Value() unexpected err = %v", err)
+			}
+
+			if !reflect.DeepEqual(value, tc.wantValue) {
+				t.Errorf("NullNID.Value() = %v; want = %v", value, tc.wantValue)
+			}
+		})
+	}
+}
+
+func TestNullBaseUnmarshalJSON(t *testing.T) {
+	type patch struct {
+		Base nid.NullBase `json:"base"`
+	}
+
+	tt := []struct {
+		name    string
+		src     string
+		want    nid.NullBase
+		wantErr bool
+	}{
+		{
+			name: "missing",
+			src:  `{}`,
+			want: nid.NullBase{},
+		},
+		{
+			name: "null",
+			src:  `{"base":null}`,
+			want: nid.NullBase{Present: true},
+		},
+		{
+			name: "empty_string",
+			src:  `{"base":""}`,
+			want: nid.NullBase{Present: true},
+		},
+		{
+			name: "value",
+			src:  `{"base":"000034o1ibe7u02570ak9evj9s"}`,
+			want: nid.NullBase{
+				Base:    nid.MustParseBase("000034o1ibe7u02570ak9evj9s"),
+				Valid:   true,
+				Present: true,
+			},
+		},
+		{
+			name:    "invalid",
+			src:     `{"base":"0034o1ibe7u02570ak9evj9s"}`,
+			want:    nid.NullBase{Present: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got patch
+
+			err := json.Unmarshal([]byte(tc.src), &got)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("NullBase.UnmarshalJSON() = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if got.Base != tc.want {
+				t.Errorf("NullBase.UnmarshalJSON() = %+v; want = %+v", got.Base, tc.want)
+			}
+		})
+	}
+}
+
+func TestNullBaseMarshalJSON(t *testing.T) {
+	tt := []struct {
+		name string
+		base nid.NullBase
+		want []byte
+	}{
+		{
+			name: "invalid",
+			base: nid.NullBase{Base: nid.MustParseBase("000034o1ibe7u02570ak9evj9s")},
+			want: []byte("null"),
+		},
+		{
+			name: "valid",
+			base: nid.NullBase{Base: nid.MustParseBase("000034o1ibe7u02570ak9evj9s"), Valid: true},
+			want: []byte("\"000034o1ibe7u02570ak9evj9s\""),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.base.MarshalJSON()
+			if err != nil {
+				t.Errorf("NullBase.MarshalJSON() unexpected err = %v", err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("NullBase.MarshalJSON() = %s; want = %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNullBaseScan(t *testing.T) {
+	tt := []struct {
+		name      string
+		src       any
+		want      nid.NullBase
+		wantValue driver.Value
+		wantErr   bool
+	}{
+		{
+			name:      "nil",
+			src:       nil,
+			want:      nid.NullBase{},
+			wantValue: nil,
+		},
+		{
+			name: "bytes",
+			src: []byte{
+				0x00, 0x00, 0x01, 0x93, 0x01, 0x92, 0xdc, 0x7f,
+				0x00, 0x45, 0x38, 0x15, 0x44, 0xbb, 0xf3, 0x4f,
+			},
+			want: nid.NullBase{Base: nid.MustParseBase("000034o1ibe7u02570ak9evj9s"), Valid: true},
+			wantValue: []byte{
+				0x00, 0x00, 0x01, 0x93, 0x01, 0x92, 0xdc, 0x7f,
+				0x00, 0x45, 0x38, 0x15, 0x44, 0xbb, 0xf3, 0x4f,
+			},
+		},
+		{
+			name:      "empty_string",
+			src:       "",
+			want:      nid.NullBase{},
+			wantValue: nil,
+		},
+		{
+			name:      "empty_bytes",
+			src:       []byte{},
+			want:      nid.NullBase{},
+			wantValue: nil,
+		},
+		{
+			name:    "bool",
+			src:     true,
+			want:    nid.NullBase{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got nid.NullBase
+
+			err := got.Scan(tc.src)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("NullBase.Scan() = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("NullBase.Scan() = %+v; want = %+v", got, tc.want)
+			}
+
+			value, err := got.Value()
+			if err != nil {
+				t.Errorf("NullBase.Value() unexpected err = %v", err)
+			}
+
+			if !reflect.DeepEqual(value, tc.wantValue) {
+				t.Errorf("NullBase.Value() = %v; want = %v", value, tc.wantValue)
+			}
+		})
+	}
+}
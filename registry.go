@@ -0,0 +1,80 @@
+package nid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Registry is a set of the [Naming]s used to parse the [NID]s of any of them.
+// Unlike the package [Parse], it honours the separators and name rules of the registered [Naming]s.
+type Registry struct {
+	namings map[string]Naming
+	seps    string
+}
+
+// NewRegistry creates a new [Registry] of the [Naming]s.
+// It returns an error if a [Naming] is not initialized, scoped or the names are not unique.
+func NewRegistry(namings ...Naming) (*Registry, error) {
+	r := &Registry{namings: make(map[string]Naming, len(namings))}
+
+	for _, n := range namings {
+		if n.name == "" {
+			return nil, fmt.Errorf("%w: naming was not initialized", ErrInvalidName)
+		} else if n.namespace != "" {
+			return nil, fmt.Errorf("%w: naming must not be scoped: %s", ErrInvalidName, n.prefix())
+		}
+
+		for _, name := range append([]string{n.name}, n.Aliases()...) {
+			if _, ok := r.namings[name]; ok {
+				return nil, fmt.Errorf("%w: duplicate naming: %s", ErrInvalidName, name)
+			}
+
+			r.namings[name] = n
+		}
+
+		if sep := n.separator(); strings.IndexByte(r.seps, sep) < 0 {
+			r.seps += string(sep)
+		}
+	}
+
+	return r, nil
+}
+
+// MustRegistry is a helper to create the [Registry]. It panics if the namings are invalid.
+func MustRegistry(namings ...Naming) *Registry {
+	r, err := NewRegistry(namings...)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// Lookup returns the registered [Naming] by its name or alias.
+func (r *Registry) Lookup(name string) (Naming, bool) {
+	n, ok := r.namings[name]
+
+	return n, ok
+}
+
+// Parse the [NID] of any registered [Naming] from the string.
+// An empty string results in an empty [NID].
+func (r *Registry) Parse(str string) (NID, error) {
+	if len(str) == 0 {
+		return NID{}, nil
+	}
+
+	cut := len(str) - encoding.EncodedLen(baseLen) - 1
+	if cut <= 0 || strings.IndexByte(r.seps, str[cut]) < 0 {
+		return NID{}, fmt.Errorf("%w: invalid named identifier: %q", ErrFailedParse, str)
+	}
+
+	_, name, _ := splitNamespace(str[:cut])
+
+	n, ok := r.namings[name]
+	if !ok {
+		return NID{}, fmt.Errorf("%w: unknown identifier name: %q", ErrFailedParse, name)
+	}
+
+	return n.Parse(str)
+}
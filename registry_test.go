@@ -0,0 +1,122 @@
+package nid_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNewRegistry(t *testing.T) {
+	tt := []struct {
+		name    string
+		namings []nid.Naming
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			namings: []nid.Naming{nid.MustNaming("book"), nid.MustNaming("author")},
+		},
+		{
+			name:    "not_initialized",
+			namings: []nid.Naming{{}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate",
+			namings: []nid.Naming{nid.MustNaming("book"), nid.MustNaming("book", nid.WithSeparator('-'))},
+			wantErr: true,
+		},
+		{
+			name:    "scoped",
+			namings: []nid.Naming{mustScope(nid.MustNaming("book"), "acme")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nid.NewRegistry(tc.namings...)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("NewRegistry() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryParse(t *testing.T) {
+	customerIDN := nid.MustNaming("cus", nid.WithMaxNameLength(4))
+	profileIDN := nid.MustNaming("user-profile", nid.WithNameRule(nid.KebabCase), nid.WithSeparator('-'))
+	registry := nid.MustRegistry(customerIDN, profileIDN)
+
+	tt := []struct {
+		name    string
+		str     string
+		want    nid.Naming
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			str:  "",
+		},
+		{
+			name: "stripe",
+			str:  "cus_000034o1ibe7u02570ak9evj9s",
+			want: customerIDN,
+		},
+		{
+			name: "kebab",
+			str:  "user-profile-000034o1ibe7u02570ak9evj9s",
+			want: profileIDN,
+		},
+		{
+			name: "kebab_namespace",
+			str:  "acme.user-profile-000034o1ibe7u02570ak9evj9s",
+			want: profileIDN,
+		},
+		{
+			name:    "wrong_separator",
+			str:     "cus-000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "unknown",
+			str:     "book_000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "short",
+			str:     "000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := registry.Parse(tc.str)
+			if tc.wantErr == (err == nil) {
+				t.Fatalf("Registry.Parse() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr {
+				if !errors.Is(err, nid.ErrFailedParse) {
+					t.Errorf("Registry.Parse() err = %v; want = %v", err, nid.ErrFailedParse)
+				}
+
+				return
+			}
+
+			if got.String() != tc.str {
+				t.Errorf("Registry.Parse() = %s; want = %s", got, tc.str)
+			}
+
+			if tc.str != "" && !tc.want.Is(got) {
+				t.Errorf("Registry.Parse() = %s; doesn't match naming %s", got, tc.want.Name())
+			}
+		})
+	}
+
+	if n, ok := registry.Lookup("cus"); !ok || n.Name() != "cus" {
+		t.Errorf("Registry.Lookup(cus) = %v, %v", n.Name(), ok)
+	}
+}
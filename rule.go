@@ -0,0 +1,100 @@
+package nid
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultSep = '_'
+	separators = "_-:~"
+)
+
+// NameRule validates the name of the [Naming], see [WithNameRule].
+type NameRule func(name string) bool
+
+// SnakeCase is the default [NameRule]. It accepts lowercase snake_case names, e.g. "user_profile".
+func SnakeCase(name string) bool {
+	return validateName(name)
+}
+
+// KebabCase is a [NameRule] that accepts lowercase kebab-case names, e.g. "user-profile".
+func KebabCase(name string) bool {
+	return !strings.Contains(name, "_") && validateName(strings.ReplaceAll(name, "-", "_"))
+}
+
+// WithSeparator sets the separator between the name and the base, e.g. '-' for "user-profile-<base>".
+// The separator must be one of '_', '-', ':' or '~'. The default is '_'.
+// The package [Parse] accepts any of them, while the [Naming.Parse] checks the [Naming] one.
+func WithSeparator(sep byte) Option {
+	return func(cfg *config) error {
+		if strings.IndexByte(separators, sep) < 0 {
+			return fmt.Errorf("%w: separator must be one of %q: %q", ErrInvalidOption, separators, sep)
+		}
+
+		cfg.sep = sep
+		if sep == defaultSep {
+			cfg.sep = 0
+		}
+
+		return nil
+	}
+}
+
+// WithNameRule sets the [NameRule] used to validate the name of the [Naming], e.g. [KebabCase].
+// Regardless of the rule, the name must not be empty and must not contain dots or spaces.
+//
+// The package [Parse] accepts the lowercase names with any of the separators of the [WithSeparator].
+// The [NID]s with other names, e.g. "Customer", can be parsed with the [Naming.Parse] or the [Registry.Parse] only,
+// so their [NID.MarshalText], [NID.MarshalJSON] and [NID.Value] fail. Use the [Naming.Column] to store them.
+func WithNameRule(rule NameRule) Option {
+	return func(cfg *config) error {
+		if rule == nil {
+			return fmt.Errorf("%w: name rule must not be nil", ErrInvalidOption)
+		}
+
+		cfg.rule = rule
+
+		return nil
+	}
+}
+
+// WithMaxNameLength limits the length of the name of the [Naming], e.g. 4 for Stripe-like "cus_" prefixes.
+func WithMaxNameLength(n int) Option {
+	return func(cfg *config) error {
+		if n <= 0 {
+			return fmt.Errorf("%w: max name length must be positive: %d", ErrInvalidOption, n)
+		}
+
+		cfg.maxLen = n
+
+		return nil
+	}
+}
+
+// sep returns the separator of the [Naming] as stored in the [NID].
+func (n Naming) sep() byte {
+	if n.cfg == nil {
+		return 0
+	}
+
+	return n.cfg.sep
+}
+
+// separator returns the separator between the name and the base.
+func (n Naming) separator() byte {
+	if sep := n.sep(); sep != 0 {
+		return sep
+	}
+
+	return defaultSep
+}
+
+// normalizeSeparator maps the allowed separators to the default one.
+func normalizeSeparator(r rune) rune {
+	if r < 0x80 && strings.IndexByte(separators, byte(r)) >= 0 {
+		return defaultSep
+	}
+
+	return r
+}
@@ -0,0 +1,279 @@
+package nid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNewNamingOptions(t *testing.T) {
+	tt := []struct {
+		name    string
+		input   string
+		opts    []nid.Option
+		wantErr error
+	}{
+		{
+			name:  "kebab",
+			input: "user-profile",
+			opts:  []nid.Option{nid.WithNameRule(nid.KebabCase), nid.WithSeparator('-')},
+		},
+		{
+			name:    "kebab_with_underscore",
+			input:   "user_profile",
+			opts:    []nid.Option{nid.WithNameRule(nid.KebabCase)},
+			wantErr: nid.ErrInvalidName,
+		},
+		{
+			name:    "kebab_default_rule",
+			input:   "user-profile",
+			wantErr: nid.ErrInvalidName,
+		},
+		{
+			name:    "rule_with_dot",
+			input:   "user.profile",
+			opts:    []nid.Option{nid.WithNameRule(func(string) bool { return true })},
+			wantErr: nid.ErrInvalidName,
+		},
+		{
+			name:  "short_prefix",
+			input: "cus",
+			opts:  []nid.Option{nid.WithMaxNameLength(4)},
+		},
+		{
+			name:    "too_long_prefix",
+			input:   "customer",
+			opts:    []nid.Option{nid.WithMaxNameLength(4)},
+			wantErr: nid.ErrInvalidName,
+		},
+		{
+			name:    "invalid_max_length",
+			input:   "cus",
+			opts:    []nid.Option{nid.WithMaxNameLength(0)},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "invalid_separator",
+			input:   "user",
+			opts:    []nid.Option{nid.WithSeparator('.')},
+			wantErr: nid.ErrInvalidOption,
+		},
+		{
+			name:    "nil_rule",
+			input:   "user",
+			opts:    []nid.Option{nid.WithNameRule(nil)},
+			wantErr: nid.ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nid.NewNaming(tc.input, tc.opts...)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Errorf("NewNaming() err = %v; want = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNaming_ParseSeparator(t *testing.T) {
+	kebabIDN := nid.MustNaming("user-profile", nid.WithNameRule(nid.KebabCase), nid.WithSeparator('-'))
+	colonIDN := nid.MustNaming("user", nid.WithSeparator(':'))
+	base := nid.MustParseBase("000034o1ibe7u02570ak9evj9s")
+
+	tt := []struct {
+		name     string
+		idn      nid.Naming
+		str      string
+		wantText string
+		wantErr  bool
+	}{
+		{
+			name:     "kebab",
+			idn:      kebabIDN,
+			str:      "user-profile-000034o1ibe7u02570ak9evj9s",
+			wantText: "user-profile-000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:     "kebab_namespace",
+			idn:      kebabIDN,
+			str:      "acme.user-profile-000034o1ibe7u02570ak9evj9s",
+			wantText: "acme.user-profile-000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:    "kebab_default_separator",
+			idn:     kebabIDN,
+			str:     "user-profile_000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:     "colon",
+			idn:      colonIDN,
+			str:      "user:000034o1ibe7u02570ak9evj9s",
+			wantText: "user:000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:    "colon_other_name",
+			idn:     colonIDN,
+			str:     "book:000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.idn.Parse(tc.str)
+			if tc.wantErr == (err == nil) {
+				t.Fatalf("Naming.Parse() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr {
+				return
+			}
+
+			if got.String() != tc.wantText || got.Base() != base || !tc.idn.Is(got) {
+				t.Errorf("Naming.Parse() = %s; want = %s", got, tc.wantText)
+			}
+
+			if !regexp.MustCompile(tc.idn.JSONSchema().Pattern).MatchString(tc.str) {
+				t.Errorf("Naming.JSONSchema().Pattern = %s; doesn't match %s", tc.idn.JSONSchema().Pattern, tc.str)
+			}
+
+			if parsed, err := nid.Parse(tc.str); err != nil || parsed != got {
+				t.Errorf("Parse(%s) = %v, %v; want = %v", tc.str, parsed, err, got)
+			}
+		})
+	}
+
+	if got := colonIDN.Apply(base); got == nid.MustNaming("user").Apply(base) || nid.Compare(got, nid.MustNaming("user").Apply(base)) == 0 {
+		t.Errorf("NID with different separators are equal")
+	}
+}
+
+func TestKebabCase(t *testing.T) {
+	tt := map[string]bool{
+		"user":         true,
+		"user-profile": true,
+		"v2-user":      true,
+		"user_profile": false,
+		"-user":        false,
+		"user-":        false,
+		"user--a":      false,
+		"2user":        false,
+	}
+
+	for name, want := range tt {
+		if got := nid.KebabCase(name); got != want {
+			t.Errorf("KebabCase(%s) = %v; want = %v", name, got, want)
+		}
+	}
+}
+
+func TestNIDRoundTripSeparator(t *testing.T) {
+	tt := []struct {
+		name string
+		idn  nid.Naming
+	}{
+		{
+			name: "dash",
+			idn:  nid.MustNaming("cus", nid.WithSeparator('-')),
+		},
+		{
+			name: "kebab",
+			idn:  nid.MustNaming("user-profile", nid.WithNameRule(nid.KebabCase)),
+		},
+		{
+			name: "kebab_dash",
+			idn:  nid.MustNaming("user-profile", nid.WithNameRule(nid.KebabCase), nid.WithSeparator('-')),
+		},
+		{
+			name: "colon_namespace",
+			idn:  mustScope(nid.MustNaming("user", nid.WithSeparator(':')), "acme"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			id := tc.idn.New()
+
+			if got, err := nid.Parse(id.String()); err != nil || got != id {
+				t.Errorf("Parse(%s) = %v, %v; want = %v", id, got, err, id)
+			}
+
+			data, err := json.Marshal(id)
+			if err != nil {
+				t.Fatalf("json.Marshal() unexpected err = %v", err)
+			}
+
+			var fromJSON nid.NID
+			if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON != id {
+				t.Errorf("json.Unmarshal(%s) = %v, %v; want = %v", data, fromJSON, err, id)
+			}
+
+			value, err := id.Value()
+			if err != nil {
+				t.Fatalf("NID.Value() unexpected err = %v", err)
+			}
+
+			var scanned nid.NID
+			if err := scanned.Scan(value); err != nil || scanned != id {
+				t.Errorf("NID.Scan(%v) = %v, %v; want = %v", value, scanned, err, id)
+			}
+
+			var flagged nid.NID
+			if err := flagged.Set(id.String()); err != nil || flagged != id {
+				t.Errorf("NID.Set(%s) = %v, %v; want = %v", id, flagged, err, id)
+			}
+
+			if want := "nid.MustParse(\"" + id.String() + "\")"; id.GoString() != want {
+				t.Errorf("NID.GoString() = %s; want = %s", id.GoString(), want)
+			}
+
+			if !tc.idn.Is(scanned) {
+				t.Errorf("Naming.Is(%v) = false; want = true", scanned)
+			}
+		})
+	}
+}
+
+func TestNIDUnmarshalTextResetsSeparator(t *testing.T) {
+	id := nid.MustNaming("user", nid.WithSeparator('-')).New()
+	str := "user_000034o1ibe7u02570ak9evj9s"
+
+	if err := id.UnmarshalText([]byte(str)); err != nil {
+		t.Fatalf("NID.UnmarshalText() unexpected err = %v", err)
+	}
+
+	if id.String() != str || id != nid.MustParse(str) {
+		t.Errorf("NID.UnmarshalText() = %s; want = %s", id, str)
+	}
+}
+
+func TestNIDCustomRuleText(t *testing.T) {
+	idn := nid.MustNaming("Customer", nid.WithNameRule(func(string) bool { return true }))
+	id := idn.New()
+
+	if _, err := id.MarshalText(); !errors.Is(err, nid.ErrInvalidName) {
+		t.Errorf("NID.MarshalText() err = %v; want = %v", err, nid.ErrInvalidName)
+	}
+
+	if _, err := json.Marshal(id); !errors.Is(err, nid.ErrInvalidName) {
+		t.Errorf("json.Marshal() err = %v; want = %v", err, nid.ErrInvalidName)
+	}
+
+	if _, err := id.Value(); !errors.Is(err, nid.ErrInvalidName) {
+		t.Errorf("NID.Value() err = %v; want = %v", err, nid.ErrInvalidName)
+	}
+
+	if strings.Contains(id.GoString(), "MustParse") {
+		t.Errorf("NID.GoString() = %s; want no MustParse", id.GoString())
+	}
+
+	if got, err := idn.Parse(id.String()); err != nil || got != id {
+		t.Errorf("Naming.Parse(%s) = %v, %v; want = %v", id, got, err, id)
+	}
+}
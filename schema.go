@@ -0,0 +1,183 @@
+package nid
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	basePattern    = "[0-9a-v]{26}"
+	opaquePattern  = "[0-9a-v]{27}"
+	segmentPattern = "[a-z][a-z0-9]*(?:[-_:~][a-z0-9]+)*"
+	exampleBase    = "000034o1ibe7u02570ak9evj9s"
+	baseSummary    = "The base is 26 base32hex characters (0-9, a-v) encoding 8 bytes of the creation time " +
+		"in Unix milliseconds followed by 8 random bytes, so identifiers sort by creation time."
+)
+
+// Schema is a JSON Schema describing the text representation of the identifier.
+// It's encoded to JSON as a schema object, so it can be embedded into OpenAPI documents.
+//
+// The schemas describe the non-empty identifiers only, while the empty [NID] and [Base] are encoded as JSON null.
+// Set the Nullable to describe the optional fields, see [Schema.OrNull].
+type Schema struct {
+	Type        string `json:"type"`
+	Pattern     string `json:"pattern"`
+	MinLength   int    `json:"minLength,omitempty"`
+	MaxLength   int    `json:"maxLength,omitempty"`
+	Description string `json:"description,omitempty"`
+	Example     string `json:"example,omitempty"`
+	// Nullable allows the JSON null, so the type is encoded as ["string", "null"].
+	Nullable bool `json:"-"`
+}
+
+// OrNull returns a copy of the [Schema] that also accepts the JSON null of the empty identifiers.
+func (s Schema) OrNull() Schema {
+	s.Nullable = true
+
+	return s
+}
+
+// MarshalJSON returns the JSON Schema object.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+
+	return json.Marshal(struct {
+		Type any `json:"type"`
+		schema
+	}{Type: s.types(), schema: schema(s)})
+}
+
+// SchemaProvider is implemented by the identifier types and the [Naming] to provide their JSON Schema.
+// OpenAPI generators can check for it to describe identifier fields precisely.
+type SchemaProvider interface {
+	JSONSchema() Schema
+}
+
+// Map returns the [Schema] as a map, which is accepted by most OpenAPI generators as a raw schema.
+func (s Schema) Map() map[string]any {
+	dst := map[string]any{
+		"type":    s.types(),
+		"pattern": s.Pattern,
+	}
+
+	if s.MinLength > 0 {
+		dst["minLength"] = s.MinLength
+	}
+
+	if s.MaxLength > 0 {
+		dst["maxLength"] = s.MaxLength
+	}
+
+	if s.Description != "" {
+		dst["description"] = s.Description
+	}
+
+	if s.Example != "" {
+		dst["example"] = s.Example
+	}
+
+	return dst
+}
+
+// types returns the type of the [Schema], adding the null type if it's nullable.
+func (s Schema) types() any {
+	if s.Nullable {
+		return []any{s.Type, "null"}
+	}
+
+	return s.Type
+}
+
+// JSONSchema returns the [Schema] of the identifiers created by the [Naming].
+// The pattern only accepts the "<name>_<base>" strings with the [Naming] name.
+// The scoped [Naming] requires its namespace, while the unscoped one accepts any namespace.
+// If the [Naming] has a [Cipher], the schema describes the [Naming.External] representation.
+func (n Naming) JSONSchema() Schema {
+	n.initialized()
+
+	var (
+		sep     = string(n.separator())
+		name    = regexp.QuoteMeta(n.name)
+		pattern = "^" + regexp.QuoteMeta(n.prefix()+sep)
+		format  = n.prefix() + sep
+		length  = len(n.prefix()) + 1 + encoding.EncodedLen(baseLen)
+		exact   = true
+	)
+
+	if aliases := n.Aliases(); len(aliases) > 0 {
+		for _, alias := range aliases {
+			name += "|" + regexp.QuoteMeta(alias)
+			length = min(length, len(n.prefix())-len(n.name)+len(alias)+1+encoding.EncodedLen(baseLen))
+		}
+
+		name = "(?:" + name + ")"
+		pattern = "^" + regexp.QuoteMeta(strings.TrimSuffix(n.prefix(), n.name)) + name + regexp.QuoteMeta(sep)
+		exact = false
+	}
+
+	if n.namespace == "" {
+		pattern = "^(?:" + segmentPattern + "\\.)*" + name + regexp.QuoteMeta(sep)
+		format = "[<namespace>.]" + format
+		exact = false
+	}
+
+	schema := Schema{
+		Type:        "string",
+		Pattern:     pattern + basePattern + "$",
+		MinLength:   length,
+		Description: "Identifier of the " + strconv.Quote(n.name) + " resource in the \"" + format + "<base>\" format. " + n.Layout().summary(),
+		Example:     n.prefix() + sep + exampleBase,
+	}
+
+	if c := n.cipher(); c != nil {
+		schema.Pattern = pattern + opaquePattern + "$"
+		schema.MinLength++
+		schema.Description = "Opaque identifier of the " + strconv.Quote(n.name) + " resource in the \"" + format + "<key><base>\" format."
+		schema.Example = n.prefix() + sep + "0" + exampleBase
+	}
+
+	if exact {
+		schema.MaxLength = schema.MinLength
+	}
+
+	return schema
+}
+
+// summary describes the text representation of the [Base] of the [Layout].
+func (l Layout) summary() string {
+	if l == LayoutDefault {
+		return baseSummary
+	}
+
+	return "The base is 26 base32hex characters (0-9, a-v) encoding the 4-bit " + strconv.Quote(l.String()) +
+		" layout tag, the creation time and the random bits, so identifiers sort by creation time."
+}
+
+// JSONSchema returns the [Schema] of any [NID] regardless of its name and namespace.
+func (NID) JSONSchema() Schema {
+	return Schema{
+		Type:      "string",
+		Pattern:   "^(?:" + segmentPattern + "\\.)*" + segmentPattern + "[-_:~]" + basePattern + "$",
+		MinLength: 2 + encoding.EncodedLen(baseLen),
+		Description: "Named identifier in the \"[<namespace>.]<name>_<base>\" format, where the name is a snake_case string " +
+			"and the namespace is a dot-separated list of snake_case strings. The names and the separator before the base " +
+			"may use any of the \"_-:~\" characters. " + baseSummary,
+		Example: "book_" + exampleBase,
+	}
+}
+
+// JSONSchema returns the [Schema] of the [Base].
+func (Base) JSONSchema() Schema {
+	length := encoding.EncodedLen(baseLen)
+
+	return Schema{
+		Type:        "string",
+		Pattern:     "^" + basePattern + "$",
+		MinLength:   length,
+		MaxLength:   length,
+		Description: "Base identifier. " + baseSummary,
+		Example:     exampleBase,
+	}
+}
@@ -0,0 +1,168 @@
+package nid_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestJSONSchema(t *testing.T) {
+	tt := []struct {
+		name      string
+		provider  nid.SchemaProvider
+		valid     []string
+		invalid   []string
+		wantRange [2]int
+	}{
+		{
+			name:     "naming",
+			provider: nid.MustNaming("user_profile"),
+			valid: []string{
+				"user_profile_000034o1ibe7u02570ak9evj9s",
+				"acme.eu.user_profile_000034o1ibe7u02570ak9evj9s",
+				nid.MustNaming("user_profile").New().String(),
+			},
+			invalid: []string{
+				"user_000034o1ibe7u02570ak9evj9s",
+				"user_profile_000034o1ibe7u02570ak9evj9",
+				"user_profile_000034o1ibe7u02570ak9evjzz",
+				".user_profile_000034o1ibe7u02570ak9evj9s",
+			},
+			wantRange: [2]int{39, 0},
+		},
+		{
+			name:     "scoped_naming",
+			provider: mustScope(nid.MustNaming("invoice"), "acme"),
+			valid: []string{
+				"acme.invoice_000034o1ibe7u02570ak9evj9s",
+			},
+			invalid: []string{
+				"invoice_000034o1ibe7u02570ak9evj9s",
+				"other.invoice_000034o1ibe7u02570ak9evj9s",
+				"acme.eu.invoice_000034o1ibe7u02570ak9evj9s",
+			},
+			wantRange: [2]int{39, 39},
+		},
+		{
+			name:     "nid",
+			provider: nid.NID{},
+			valid: []string{
+				"user_profile_000034o1ibe7u02570ak9evj9s",
+				"b_000034o1ibe7u02570ak9evj9s",
+				"v2_000034o1ibe7u02570ak9evj9s",
+				"invoice_000034o1ibe7u02570ak9evj9s.line_000034o1ibe7u02570ak9evj9s",
+				"user-profile-000034o1ibe7u02570ak9evj9s",
+				"acme.cus:000034o1ibe7u02570ak9evj9s",
+				nid.MustNaming("user-profile", nid.WithNameRule(nid.KebabCase), nid.WithSeparator('~')).New().String(),
+			},
+			invalid: []string{
+				"000034o1ibe7u02570ak9evj9s",
+				"user--profile-000034o1ibe7u02570ak9evj9s",
+				"user.000034o1ibe7u02570ak9evj9s",
+				"_000034o1ibe7u02570ak9evj9s",
+				"2v_000034o1ibe7u02570ak9evj9s",
+				"user__profile_000034o1ibe7u02570ak9evj9s",
+				"User_000034o1ibe7u02570ak9evj9s",
+				"acme..user_000034o1ibe7u02570ak9evj9s",
+			},
+			wantRange: [2]int{28, 0},
+		},
+		{
+			name:     "base",
+			provider: nid.Base{},
+			valid: []string{
+				"000034o1ibe7u02570ak9evj9s",
+				nid.NewBase().String(),
+			},
+			invalid: []string{
+				"book_000034o1ibe7u02570ak9evj9s",
+				"000034o1ibe7u02570ak9evj9w",
+			},
+			wantRange: [2]int{26, 26},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			schema := tc.provider.JSONSchema()
+
+			re, err := regexp.Compile(schema.Pattern)
+			if err != nil {
+				t.Fatalf("JSONSchema().Pattern = %s; unexpected err = %v", schema.Pattern, err)
+			}
+
+			for _, str := range append(tc.valid, schema.Example) {
+				if !re.MatchString(str) {
+					t.Errorf("JSONSchema().Pattern = %s; doesn't match valid %s", schema.Pattern, str)
+				}
+			}
+
+			for _, str := range tc.invalid {
+				if re.MatchString(str) {
+					t.Errorf("JSONSchema().Pattern = %s; matches invalid %s", schema.Pattern, str)
+				}
+			}
+
+			if got := [2]int{schema.MinLength, schema.MaxLength}; got != tc.wantRange {
+				t.Errorf("JSONSchema() length = %v; want = %v", got, tc.wantRange)
+			}
+
+			data, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatalf("json.Marshal(JSONSchema()) unexpected err = %v", err)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("json.Unmarshal() unexpected err = %v", err)
+			}
+
+			want := schema.Map()
+			for k, v := range want {
+				if n, ok := v.(int); ok {
+					want[k] = float64(n)
+				}
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Schema.Map() = %v; want = %v", want, got)
+			}
+		})
+	}
+}
+
+func TestSchemaOrNull(t *testing.T) {
+	schema := nid.MustNaming("book").JSONSchema()
+
+	data, err := json.Marshal(schema)
+	if err != nil || !strings.HasPrefix(string(data), `{"type":"string","pattern":`) {
+		t.Errorf("json.Marshal(JSONSchema()) = %s, %v; want string type", data, err)
+	}
+
+	data, err = json.Marshal(schema.OrNull())
+	if err != nil || !strings.HasPrefix(string(data), `{"type":["string","null"],"pattern":`) {
+		t.Errorf("json.Marshal(JSONSchema().OrNull()) = %s, %v; want string or null type", data, err)
+	}
+
+	if schema.Nullable || !schema.OrNull().Nullable {
+		t.Errorf("Schema.OrNull() doesn't copy the schema")
+	}
+
+	empty, _ := json.Marshal(nid.NID{})
+	if types, _ := schema.OrNull().Map()["type"].([]any); string(empty) != "null" || len(types) != 2 || types[1] != "null" {
+		t.Errorf("Schema.OrNull().Map() type = %v; want to accept %s", types, empty)
+	}
+}
+
+func mustScope(n nid.Naming, namespace string) nid.Naming {
+	scoped, err := n.Scope(namespace)
+	if err != nil {
+		panic(err)
+	}
+
+	return scoped
+}
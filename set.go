@@ -0,0 +1,157 @@
+package nid
+
+import (
+	"iter"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Set is a sorted set of the [NID]s without duplicates.
+// Lookups use the binary search, while the set operations merge the sorted lists in a linear time.
+// The zero value is an empty set ready to use.
+type Set struct {
+	ids []NID
+}
+
+// NewSet creates a new [Set] from the [NID]s. The slice is copied, sorted and deduplicated.
+func NewSet(ids ...NID) Set {
+	return Set{ids: Dedup(slices.Clone(ids))}
+}
+
+// Len returns the number of the [NID]s in the [Set].
+func (s Set) Len() int {
+	return len(s.ids)
+}
+
+// IDs returns the sorted [NID]s of the [Set]. The slice must not be modified.
+func (s Set) IDs() []NID {
+	return s.ids
+}
+
+// All returns the sequence of the [NID]s of the [Set] in sort order.
+func (s Set) All() iter.Seq[NID] {
+	return slices.Values(s.ids)
+}
+
+// Contains returns true if the [Set] contains the [NID].
+func (s Set) Contains(id NID) bool {
+	_, ok := slices.BinarySearchFunc(s.ids, id, Compare)
+
+	return ok
+}
+
+// Insert adds the [NID] to the [Set]. It returns false if the [NID] is already in the [Set].
+func (s *Set) Insert(id NID) bool {
+	i, ok := slices.BinarySearchFunc(s.ids, id, Compare)
+	if ok {
+		return false
+	}
+
+	s.ids = slices.Insert(s.ids, i, id)
+
+	return true
+}
+
+// Remove deletes the [NID] from the [Set]. It returns false if the [NID] is not in the [Set].
+func (s *Set) Remove(id NID) bool {
+	i, ok := slices.BinarySearchFunc(s.ids, id, Compare)
+	if !ok {
+		return false
+	}
+
+	s.ids = slices.Delete(s.ids, i, i+1)
+
+	return true
+}
+
+// Union returns a new [Set] with the [NID]s of both sets.
+func (s Set) Union(other Set) Set {
+	return s.merge(other, true, true, true)
+}
+
+// Intersect returns a new [Set] with the [NID]s present in both sets.
+func (s Set) Intersect(other Set) Set {
+	return s.merge(other, false, true, false)
+}
+
+// Difference returns a new [Set] with the [NID]s of the [Set] missing in the other one.
+func (s Set) Difference(other Set) Set {
+	return s.merge(other, true, false, false)
+}
+
+// Between returns a new [Set] with the [NID]s created within the half-open time range [from, to).
+// Every name and [Layout] is a sorted run of the [Set], so both the runs and the range within each of them
+// are found with the binary search. The bounds are rounded up to the [Layout.Precision], since the [Base]
+// created at the time t is within the range if t >= from and t < to.
+func (s Set) Between(from, to time.Time) Set {
+	var dst []NID
+
+	for start := 0; start < len(s.ids); {
+		rest := s.ids[start:]
+		group := rest[:sort.Search(len(rest), func(i int) bool { return !sameName(rest[0], rest[i]) })]
+
+		for l := range Layout(len(layouts)) {
+			lo := s.search(group, l.MinBaseAt(l.ceil(from)))
+			hi := s.search(group, l.MinBaseAt(l.ceil(to)))
+			dst = append(dst, group[lo:hi]...)
+		}
+
+		start += len(group)
+	}
+
+	return Set{ids: dst}
+}
+
+// search returns the index of the first [NID] of the group with the [Base] not less than the given one.
+func (Set) search(group []NID, base Base) int {
+	i, _ := slices.BinarySearchFunc(group, base, func(id NID, base Base) int {
+		return CompareBase(id.base, base)
+	})
+
+	return i
+}
+
+// merge walks both sorted sets, keeping the [NID]s only in the [Set], in both sets, and only in the other one.
+func (s Set) merge(other Set, onlyLeft, both, onlyRight bool) Set {
+	dst := make([]NID, 0, max(len(s.ids), len(other.ids)))
+
+	i, j := 0, 0
+	for i < len(s.ids) && j < len(other.ids) {
+		switch c := Compare(s.ids[i], other.ids[j]); {
+		case c < 0:
+			if onlyLeft {
+				dst = append(dst, s.ids[i])
+			}
+
+			i++
+		case c > 0:
+			if onlyRight {
+				dst = append(dst, other.ids[j])
+			}
+
+			j++
+		default:
+			if both {
+				dst = append(dst, s.ids[i])
+			}
+
+			i, j = i+1, j+1
+		}
+	}
+
+	if onlyLeft {
+		dst = append(dst, s.ids[i:]...)
+	}
+
+	if onlyRight {
+		dst = append(dst, other.ids[j:]...)
+	}
+
+	return Set{ids: dst}
+}
+
+// sameName returns true if the [NID]s have the same namespace, name and separator.
+func sameName(a, b NID) bool {
+	return a.namespace == b.namespace && a.name == b.name && a.sep == b.sep
+}
@@ -0,0 +1,195 @@
+package nid_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNewSet(t *testing.T) {
+	var (
+		a = nid.MustNaming("book").NewAt(time.UnixMilli(1))
+		b = nid.MustNaming("book").NewAt(time.UnixMilli(2))
+		c = nid.MustNaming("author").NewAt(time.UnixMilli(1))
+	)
+
+	set := nid.NewSet(b, a, c, b, a)
+
+	if want := []nid.NID{c, a, b}; !slices.Equal(set.IDs(), want) {
+		t.Errorf("NewSet().IDs() = %v; want = %v", set.IDs(), want)
+	}
+
+	if got := slices.Collect(set.All()); !slices.Equal(got, set.IDs()) {
+		t.Errorf("Set.All() = %v; want = %v", got, set.IDs())
+	}
+
+	for _, id := range []nid.NID{a, b, c} {
+		if !set.Contains(id) {
+			t.Errorf("Set.Contains(%v) = false; want = true", id)
+		}
+	}
+
+	if other := nid.MustNaming("book").NewAt(time.UnixMilli(3)); set.Contains(other) {
+		t.Errorf("Set.Contains(%v) = true; want = false", other)
+	}
+}
+
+func TestSetInsertRemove(t *testing.T) {
+	var (
+		set    nid.Set
+		naming = nid.MustNaming("book")
+		ids    = naming.NewN(100)
+	)
+
+	for _, i := range []int{50, 10, 99, 0, 10, 75} {
+		set.Insert(ids[i])
+	}
+
+	if want := []nid.NID{ids[0], ids[10], ids[50], ids[75], ids[99]}; !slices.Equal(set.IDs(), want) {
+		t.Errorf("Set.Insert() = %v; want = %v", set.IDs(), want)
+	}
+
+	if set.Insert(ids[50]) {
+		t.Errorf("Set.Insert(existing) = true; want = false")
+	}
+
+	if !set.Remove(ids[50]) || set.Remove(ids[50]) || set.Len() != 4 {
+		t.Errorf("Set.Remove() = %v; want 4 identifiers without %v", set.IDs(), ids[50])
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	ids := nid.MustNaming("book").NewN(6)
+
+	var (
+		left  = nid.NewSet(ids[0], ids[1], ids[2], ids[4])
+		right = nid.NewSet(ids[1], ids[3], ids[4], ids[5])
+	)
+
+	tt := []struct {
+		name string
+		got  nid.Set
+		want []nid.NID
+	}{
+		{
+			name: "union",
+			got:  left.Union(right),
+			want: ids,
+		},
+		{
+			name: "intersect",
+			got:  left.Intersect(right),
+			want: []nid.NID{ids[1], ids[4]},
+		},
+		{
+			name: "difference",
+			got:  left.Difference(right),
+			want: []nid.NID{ids[0], ids[2]},
+		},
+		{
+			name: "difference_reverse",
+			got:  right.Difference(left),
+			want: []nid.NID{ids[3], ids[5]},
+		},
+		{
+			name: "union_empty",
+			got:  left.Union(nid.Set{}),
+			want: left.IDs(),
+		},
+		{
+			name: "intersect_empty",
+			got:  nid.Set{}.Intersect(right),
+			want: []nid.NID{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !slices.Equal(tc.got.IDs(), tc.want) {
+				t.Errorf("Set = %v; want = %v", tc.got.IDs(), tc.want)
+			}
+		})
+	}
+}
+
+func TestSetBetween(t *testing.T) {
+	var (
+		book    = nid.MustNaming("book")
+		compact = nid.MustNaming("book", nid.WithLayout(nid.LayoutCompact))
+		author  = nid.MustNaming("author")
+		ts      = time.Date(2024, time.November, 6, 0, 0, 0, 0, time.UTC)
+	)
+
+	set := nid.NewSet(
+		book.NewAt(ts.Add(-time.Hour)),
+		book.NewAt(ts),
+		book.NewAt(ts.Add(time.Hour)),
+		book.NewAt(ts.Add(2*time.Hour)),
+		compact.NewAt(ts.Add(-time.Millisecond)),
+		compact.NewAt(ts.Add(30*time.Minute)),
+		author.NewAt(ts.Add(90*time.Minute)),
+		author.NewAt(ts.Add(3*time.Hour)),
+	)
+
+	got := set.Between(ts, ts.Add(2*time.Hour))
+	if got.Len() != 4 {
+		t.Errorf("Set.Between() = %v; want 4 identifiers", got.IDs())
+	}
+
+	for id := range got.All() {
+		if created := id.Base().Time(); created.Before(ts) || !created.Before(ts.Add(2*time.Hour)) {
+			t.Errorf("Set.Between() = %v created at %v; want within range", id, created)
+		}
+	}
+}
+
+func TestSetBetweenPrecision(t *testing.T) {
+	var (
+		book = nid.MustNaming("book")
+		ts   = time.Date(2024, time.November, 6, 0, 0, 0, 0, time.UTC)
+		half = 500 * time.Microsecond
+		set  = nid.NewSet(book.NewAt(ts))
+	)
+
+	tt := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want int
+	}{
+		{
+			name: "exact",
+			from: ts,
+			to:   ts.Add(time.Millisecond),
+			want: 1,
+		},
+		{
+			name: "from_after_creation",
+			from: ts.Add(half),
+			to:   ts.Add(time.Hour),
+			want: 0,
+		},
+		{
+			name: "to_after_creation",
+			from: ts.Add(-time.Hour),
+			to:   ts.Add(half),
+			want: 1,
+		},
+		{
+			name: "to_at_creation",
+			from: ts.Add(-time.Hour),
+			to:   ts,
+			want: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := set.Between(tc.from, tc.to); got.Len() != tc.want {
+				t.Errorf("Set.Between() = %v; want %d identifiers", got.IDs(), tc.want)
+			}
+		})
+	}
+}
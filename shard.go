@@ -0,0 +1,79 @@
+package nid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// PartitionKey returns a stable 64-bit partition key of the [Base].
+// It's derived from the random part only, so identifiers created at the same time are spread evenly.
+func (base Base) PartitionKey() uint64 {
+	return mix64(binary.BigEndian.Uint64(base[timeLen:]))
+}
+
+// Shard returns the shard index in the range [0, n) for the [Base].
+// The result is stable, but changing n remaps most of the identifiers, use [Base.JumpShard] to avoid it.
+// It panics if n is not positive.
+func (base Base) Shard(n int) int {
+	mustShards(n)
+
+	hi, _ := bits.Mul64(base.PartitionKey(), uint64(n))
+
+	return int(hi) //nolint:gosec
+}
+
+// JumpShard returns the shard index in the range [0, n) for the [Base] using the [JumpHash].
+// Growing the number of shards from n to n+1 moves only 1/(n+1) of the identifiers to the new shard.
+// It panics if n is not positive.
+func (base Base) JumpShard(n int) int {
+	return JumpHash(base.PartitionKey(), n)
+}
+
+// PartitionKey returns a stable 64-bit partition key of the [NID], see [Base.PartitionKey].
+// The name is not taken into account, so the same [Base] has the same key across namings.
+func (id NID) PartitionKey() uint64 {
+	return id.base.PartitionKey()
+}
+
+// Shard returns the shard index in the range [0, n) for the [NID], see [Base.Shard].
+func (id NID) Shard(n int) int {
+	return id.base.Shard(n)
+}
+
+// JumpShard returns the consistent shard index in the range [0, n) for the [NID], see [Base.JumpShard].
+func (id NID) JumpShard(n int) int {
+	return id.base.JumpShard(n)
+}
+
+// JumpHash implements the "A Fast, Minimal Memory, Consistent Hash Algorithm" by Lamping and Veach.
+// It maps the key to a bucket in the range [0, buckets). It panics if buckets is not positive.
+func JumpHash(key uint64, buckets int) int {
+	mustShards(buckets)
+
+	var b, j int64 = -1, 0
+
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+
+	return int(b)
+}
+
+// mix64 is the splitmix64 finalizer, it spreads structured values evenly across 64 bits.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+func mustShards(n int) {
+	if n <= 0 {
+		panic("nid: number of shards must be positive")
+	}
+}
@@ -0,0 +1,194 @@
+package nid_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.wamod.dev/nid"
+)
+
+func TestBaseShard(t *testing.T) {
+	ts := time.Now()
+
+	for _, shards := range []int{1, 2, 7, 16, 100} {
+		counts := make([]int, shards)
+
+		for i := 0; i < 100_000; i++ {
+			base := nid.NewBaseAt(ts)
+
+			shard := base.Shard(shards)
+			if shard < 0 || shard >= shards {
+				t.Fatalf("Base.Shard(%d) = %d; out of range", shards, shard)
+			}
+
+			if again := base.Shard(shards); again != shard {
+				t.Fatalf("Base.Shard(%d) = %d; not stable = %d", shards, again, shard)
+			}
+
+			counts[shard]++
+		}
+
+		if got, limit := chiSquare(counts); got > limit {
+			t.Errorf("Base.Shard(%d) chi-square = %.2f > %.2f; distribution is not uniform: %v", shards, got, limit, counts)
+		}
+	}
+}
+
+func TestBaseShardGolden(t *testing.T) {
+	base := nid.MustParseBase("000034o1ibe7u02570ak9evj9s")
+
+	if got, want := base.PartitionKey(), uint64(0x3c338b61c2ded0f3); got != want {
+		t.Errorf("Base.PartitionKey() = %#x; want = %#x", got, want)
+	}
+
+	tt := []struct {
+		shards   int
+		want     int
+		wantJump int
+	}{
+		{shards: 1, want: 0, wantJump: 0},
+		{shards: 2, want: 0, wantJump: 0},
+		{shards: 7, want: 1, wantJump: 0},
+		{shards: 10, want: 2, wantJump: 0},
+		{shards: 16, want: 3, wantJump: 15},
+		{shards: 64, want: 15, wantJump: 58},
+		{shards: 100, want: 23, wantJump: 83},
+		{shards: 1024, want: 240, wantJump: 847},
+	}
+
+	for _, tc := range tt {
+		t.Run(strconv.Itoa(tc.shards), func(t *testing.T) {
+			if got := base.Shard(tc.shards); got != tc.want {
+				t.Errorf("Base.Shard(%d) = %d; want = %d", tc.shards, got, tc.want)
+			}
+
+			if got := base.JumpShard(tc.shards); got != tc.wantJump {
+				t.Errorf("Base.JumpShard(%d) = %d; want = %d", tc.shards, got, tc.wantJump)
+			}
+		})
+	}
+}
+
+func TestBaseJumpShard(t *testing.T) {
+	bases := make([]nid.Base, 50_000)
+	for i := range bases {
+		bases[i] = nid.NewBase()
+	}
+
+	for _, shards := range []int{1, 10, 64} {
+		counts := make([]int, shards)
+
+		for _, base := range bases {
+			shard := base.JumpShard(shards)
+			if shard < 0 || shard >= shards {
+				t.Fatalf("Base.JumpShard(%d) = %d; out of range", shards, shard)
+			}
+
+			if next := base.JumpShard(shards + 1); next != shard && next != shards {
+				t.Fatalf("Base.JumpShard(%d) = %d; moved from %d to existing shard", shards+1, next, shard)
+			}
+
+			counts[shard]++
+		}
+
+		if got, limit := chiSquare(counts); got > limit {
+			t.Errorf("Base.JumpShard(%d) chi-square = %.2f > %.2f; distribution is not uniform: %v", shards, got, limit, counts)
+		}
+	}
+}
+
+func TestNIDShard(t *testing.T) {
+	id := nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+	other := nid.MustNaming("author").Apply(id.Base())
+
+	if id.PartitionKey() != other.PartitionKey() || id.Shard(32) != other.Shard(32) || id.JumpShard(32) != other.JumpShard(32) {
+		t.Errorf("NID shard depends on the name")
+	}
+
+	if got, want := id.Shard(32), id.Base().Shard(32); got != want {
+		t.Errorf("NID.Shard(32) = %d; want = %d", got, want)
+	}
+}
+
+func TestJumpHash(t *testing.T) {
+	tt := []struct {
+		name      string
+		key       uint64
+		buckets   int
+		want      int
+		wantPanic bool
+	}{
+		{
+			name:    "single",
+			key:     0xdeadbeef,
+			buckets: 1,
+			want:    0,
+		},
+		{
+			name:    "reference_42",
+			key:     42,
+			buckets: 57,
+			want:    43,
+		},
+		{
+			name:    "reference_dead10cc",
+			key:     0xdead10cc,
+			buckets: 666,
+			want:    361,
+		},
+		{
+			name:    "reference_256",
+			key:     256,
+			buckets: 1024,
+			want:    520,
+		},
+		{
+			name:      "zero",
+			key:       1,
+			buckets:   0,
+			wantPanic: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if tc.wantPanic == (r == nil) {
+					t.Errorf("JumpHash() panic = %v; wantPanic = %v", r, tc.wantPanic)
+				}
+			}()
+
+			if got := nid.JumpHash(tc.key, tc.buckets); got != tc.want {
+				t.Errorf("JumpHash(%d, %d) = %d; want = %d", tc.key, tc.buckets, got, tc.want)
+			}
+		})
+	}
+}
+
+// chiSquare returns the chi-square statistic of the counts and its upper limit.
+// The limit is far above the 99.99th percentile for the degrees of freedom, so the tests don't flake.
+func chiSquare(counts []int) (float64, float64) {
+	df := float64(len(counts) - 1)
+	if df < 1 {
+		return 0, 0
+	}
+
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+
+	expected := float64(total) / float64(len(counts))
+
+	var sum float64
+
+	for _, c := range counts {
+		d := float64(c) - expected
+		sum += d * d / expected
+	}
+
+	return sum, df + 8*math.Sqrt(2*df) + 10
+}
@@ -0,0 +1,106 @@
+package nid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+const (
+	minSignatureLen = 4
+	minSecretLen    = 16
+)
+
+// Signer signs the [NID]s with HMAC-SHA256, so the identifiers given to untrusted clients are tamper-evident.
+//
+// The signed text form is "<name>_<base>.<signature>".
+// The first secret is used to sign, while all secrets are used to verify, which allows to rotate the secrets.
+type Signer struct {
+	secrets [][]byte
+	size    int
+}
+
+// NewSigner creates a new [Signer] with the signature truncated to size bytes in the range [4, 32].
+// The secrets must be at least 16 bytes long. The first secret is used for signing.
+func NewSigner(size int, secrets ...[]byte) (*Signer, error) {
+	if size < minSignatureLen || size > sha256.Size {
+		return nil, fmt.Errorf("%w: signature size must be in range [%d, %d]: %d", ErrInvalidKey, minSignatureLen, sha256.Size, size)
+	} else if len(secrets) == 0 {
+		return nil, fmt.Errorf("%w: at least one secret is required", ErrInvalidKey)
+	}
+
+	s := &Signer{
+		secrets: make([][]byte, 0, len(secrets)),
+		size:    size,
+	}
+
+	for _, secret := range secrets {
+		if len(secret) < minSecretLen {
+			return nil, fmt.Errorf("%w: secret must be at least %d bytes long", ErrInvalidKey, minSecretLen)
+		}
+
+		s.secrets = append(s.secrets, append([]byte(nil), secret...))
+	}
+
+	return s, nil
+}
+
+// MustSigner is a helper to create the [Signer]. It panics if the size or secrets are invalid.
+func MustSigner(size int, secrets ...[]byte) *Signer {
+	s, err := NewSigner(size, secrets...)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Sign returns the signed text form of the [NID]. An empty [NID] results in an empty string.
+func (s *Signer) Sign(id NID) string {
+	if id.Empty() {
+		return ""
+	}
+
+	str := id.String()
+
+	return str + "." + encoding.EncodeToString(s.mac(s.secrets[0], str))
+}
+
+// Verify parses the [NID] from the signed text form and verifies its signature.
+// It returns the [ErrInvalidSignature] if the signature is missing or doesn't match any of the secrets.
+func (s *Signer) Verify(str string) (NID, error) {
+	// The namespace has dots as well, so the signature is present only if the base precedes the last dot.
+	cut, size := strings.LastIndex(str, "."), encoding.EncodedLen(baseLen)
+	if cut < size+2 || strings.IndexByte(separators, str[cut-size-1]) < 0 {
+		return NID{}, fmt.Errorf("%w: missing signature", ErrInvalidSignature)
+	}
+
+	id, err := Parse(str[:cut])
+	if err != nil {
+		return NID{}, err
+	} else if id.Empty() {
+		return NID{}, fmt.Errorf("%w: empty identifier", ErrInvalidSignature)
+	}
+
+	sig, err := encoding.DecodeString(str[cut+1:])
+	if err != nil || len(sig) != s.size {
+		return NID{}, fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+
+	for _, secret := range s.secrets {
+		if hmac.Equal(sig, s.mac(secret, str[:cut])) {
+			return id, nil
+		}
+	}
+
+	return NID{}, fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+}
+
+// mac returns the truncated HMAC of the string.
+func (s *Signer) mac(secret []byte, str string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(str))
+
+	return h.Sum(nil)[:s.size]
+}
@@ -0,0 +1,189 @@
+package nid_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestNewSigner(t *testing.T) {
+	secret := bytes.Repeat([]byte{1}, 16)
+
+	tt := []struct {
+		name    string
+		size    int
+		secrets [][]byte
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			size:    10,
+			secrets: [][]byte{secret},
+		},
+		{
+			name:    "too_short",
+			size:    3,
+			secrets: [][]byte{secret},
+			wantErr: true,
+		},
+		{
+			name:    "too_long",
+			size:    33,
+			secrets: [][]byte{secret},
+			wantErr: true,
+		},
+		{
+			name:    "no_secrets",
+			size:    10,
+			wantErr: true,
+		},
+		{
+			name:    "short_secret",
+			size:    10,
+			secrets: [][]byte{secret, []byte("short")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nid.NewSigner(tc.size, tc.secrets...)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("NewSigner() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr && !errors.Is(err, nid.ErrInvalidKey) {
+				t.Errorf("NewSigner() err = %v; want = %v", err, nid.ErrInvalidKey)
+			}
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	oldSecret := bytes.Repeat([]byte{1}, 16)
+	newSecret := bytes.Repeat([]byte{2}, 16)
+
+	oldSigner := nid.MustSigner(10, oldSecret)
+	newSigner := nid.MustSigner(10, newSecret, oldSecret)
+
+	id := nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+	signed := oldSigner.Sign(id)
+
+	if !strings.HasPrefix(signed, id.String()+".") || len(signed) != len(id.String())+1+16 {
+		t.Fatalf("Signer.Sign() = %s; want %s.<16 characters>", signed, id)
+	}
+
+	if got := nid.MustSigner(4, oldSecret).Sign(id); !strings.HasPrefix(signed, got[:len(got)-1]) {
+		t.Errorf("Signer.Sign() truncated = %s; want prefix of %s", got, signed)
+	}
+
+	if got := oldSigner.Sign(nid.NID{}); got != "" {
+		t.Errorf("Signer.Sign(empty) = %s; want empty", got)
+	}
+
+	kebab := nid.MustNaming("user-profile", nid.WithNameRule(nid.KebabCase), nid.WithSeparator('-')).Apply(id.Base())
+	tampered := nid.MustParse("book_000034o1ibe7u02570ak9evk9s").String() + signed[len(id.String()):]
+
+	tt := []struct {
+		name    string
+		signer  *nid.Signer
+		str     string
+		want    nid.NID
+		wantErr error
+	}{
+		{
+			name:   "valid",
+			signer: oldSigner,
+			str:    signed,
+			want:   id,
+		},
+		{
+			name:   "custom_separator",
+			signer: oldSigner,
+			str:    oldSigner.Sign(kebab),
+			want:   kebab,
+		},
+		{
+			name:   "namespace",
+			signer: oldSigner,
+			str:    oldSigner.Sign(nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s")),
+			want:   nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+		},
+		{
+			name:   "rotated",
+			signer: newSigner,
+			str:    signed,
+			want:   id,
+		},
+		{
+			name:    "unknown_secret",
+			signer:  nid.MustSigner(10, newSecret),
+			str:     signed,
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "tampered",
+			signer:  oldSigner,
+			str:     tampered,
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "unsigned",
+			signer:  oldSigner,
+			str:     id.String(),
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "unsigned_namespace",
+			signer:  oldSigner,
+			str:     "acme.book_000034o1ibe7u02570ak9evj9s",
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "unsigned_parent",
+			signer:  oldSigner,
+			str:     "invoice_000034o1ibe7u02570ak9evj9s.line_000034o1ibe7u02570ak9evj9s",
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "empty",
+			signer:  oldSigner,
+			str:     "",
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "empty_id",
+			signer:  oldSigner,
+			str:     "." + signed[len(id.String())+1:],
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "truncated_signature",
+			signer:  oldSigner,
+			str:     signed[:len(signed)-2],
+			wantErr: nid.ErrInvalidSignature,
+		},
+		{
+			name:    "invalid_id",
+			signer:  oldSigner,
+			str:     "book_!00034o1ibe7u02570ak9evj9s" + signed[len(id.String()):],
+			wantErr: nid.ErrFailedParse,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.signer.Verify(tc.str)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Errorf("Signer.Verify() err = %v; want = %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("Signer.Verify() = %s; want = %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -11,3 +11,17 @@ func Sort(ids []NID) {
 func SortBase(ids []Base) {
 	slices.SortFunc(ids, CompareBase)
 }
+
+// Dedup sorts the [NID]s and removes the duplicates in place. It returns the shortened slice.
+func Dedup(ids []NID) []NID {
+	Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// DedupBase sorts the [Base] identifiers and removes the duplicates in place. It returns the shortened slice.
+func DedupBase(ids []Base) []Base {
+	SortBase(ids)
+
+	return slices.Compact(ids)
+}
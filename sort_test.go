@@ -63,3 +63,47 @@ func TestSortBase(t *testing.T) {
 		}
 	}
 }
+
+func TestDedup(t *testing.T) {
+	list := []nid.NID{
+		nid.MustNaming("example_b").Apply(nid.Base{1}),
+		nid.MustNaming("example").Apply(nid.Base{1}),
+		nid.MustNaming("example_b").Apply(nid.Base{1}),
+		{},
+		nid.MustNaming("example").Apply(nid.Base{1}),
+		{},
+	}
+
+	want := []nid.NID{
+		{},
+		nid.MustNaming("example").Apply(nid.Base{1}),
+		nid.MustNaming("example_b").Apply(nid.Base{1}),
+	}
+
+	got := nid.Dedup(list)
+	if len(got) != len(want) {
+		t.Fatalf("Dedup() = %v; want = %v", got, want)
+	}
+
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("Dedup()[%d] = %s, want = %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDedupBase(t *testing.T) {
+	list := []nid.Base{{2}, {}, {1}, {2}, {}}
+	want := []nid.Base{{}, {1}, {2}}
+
+	got := nid.DedupBase(list)
+	if len(got) != len(want) {
+		t.Fatalf("DedupBase() = %v; want = %v", got, want)
+	}
+
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("DedupBase()[%d] = %s, want = %s", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package nid
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+const uuidLen = 36
+
+// Storage is a format used to store the [NID] in the database.
+type Storage int
+
+const (
+	// StorageText stores the full "<name>_<base>" string. It's the default format.
+	StorageText Storage = iota
+	// StorageBase stores the [Base] string without the name.
+	StorageBase
+	// StorageBinary stores the [Base] as 16 raw bytes.
+	StorageBinary
+	// StorageUUID stores the [Base] as a UUID string, e.g. "00000193-0192-dc7f-0045-381544bbf34f".
+	StorageUUID
+)
+
+// String returns the name of the storage format.
+func (s Storage) String() string {
+	switch s {
+	case StorageText:
+		return "text"
+	case StorageBase:
+		return "base"
+	case StorageBinary:
+		return "binary"
+	case StorageUUID:
+		return "uuid"
+	default:
+		return fmt.Sprintf("Storage(%d)", int(s))
+	}
+}
+
+// valid returns true if the storage format is known.
+func (s Storage) valid() bool {
+	return s >= StorageText && s <= StorageUUID
+}
+
+// Column is a nullable database column of the [NID] stored in the [Naming] storage format.
+// An empty [NID] is stored as NULL.
+//
+// Only the [StorageText] keeps the namespace, the other formats restore it from the [Naming] when scanned,
+// so the [NID] must have the namespace of the [Naming] to be stored.
+type Column struct {
+	naming Naming
+	id     *NID
+}
+
+// Value returns the driver value of the [NID] in the [Naming] storage format.
+// A nil [NID] pointer is stored as NULL.
+func (c Column) Value() (driver.Value, error) {
+	if c.id == nil || c.id.Empty() {
+		return nil, nil
+	}
+
+	id := *c.id
+
+	if !c.naming.Is(id) {
+		return nil, fmt.Errorf("%w: identifier %q doesn't match naming %q", ErrInvalidName, id, c.naming.prefix())
+	}
+
+	id = c.naming.canonicalize(id)
+
+	if c.naming.Storage() != StorageText && id.namespace != c.naming.namespace {
+		return nil, fmt.Errorf("%w: %s storage drops the namespace of identifier %q, use a naming scoped to it",
+			ErrInvalidName, c.naming.Storage(), id)
+	}
+
+	switch c.naming.Storage() {
+	case StorageBase:
+		return id.base.String(), nil
+	case StorageBinary:
+		return id.base.Bytes(), nil
+	case StorageUUID:
+		return formatUUID(id.base), nil
+	default:
+		return id.String(), nil
+	}
+}
+
+// Scan the value stored in the [Naming] storage format into the [NID].
+// It returns an error if the [NID] pointer is nil.
+func (c Column) Scan(src any) error {
+	if c.id == nil {
+		return fmt.Errorf("%w: nil scan destination", ErrFailedParse)
+	}
+
+	var (
+		id  NID
+		err error
+	)
+
+	switch c.naming.Storage() {
+	case StorageBase:
+		id, err = c.scanBase(src, scanBaseText)
+	case StorageBinary:
+		id, err = c.scanBase(src, (*Base).Scan)
+	case StorageUUID:
+		id, err = c.scanBase(src, scanBaseUUID)
+	default:
+		id, err = c.scanText(src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*c.id = id
+
+	return nil
+}
+
+func (c Column) scanText(src any) (NID, error) {
+	switch src := src.(type) {
+	case nil:
+		return NID{}, nil
+	case string:
+		return c.naming.Parse(src)
+	case []byte:
+		return c.naming.Parse(string(src))
+	default:
+		return NID{}, fmt.Errorf("%w: invalid scan source: %T", ErrFailedParse, src)
+	}
+}
+
+func (c Column) scanBase(src any, scan func(*Base, any) error) (NID, error) {
+	var base Base
+
+	err := scan(&base, src)
+	if err != nil {
+		return NID{}, err
+	}
+
+	return c.naming.Apply(base), nil
+}
+
+func scanBaseText(base *Base, src any) error {
+	switch src := src.(type) {
+	case nil:
+		*base = Base{}
+
+		return nil
+	case string:
+		return base.UnmarshalText([]byte(src))
+	case []byte:
+		return base.UnmarshalText(src)
+	default:
+		return fmt.Errorf("%w: invalid scan source: %T", ErrFailedParse, src)
+	}
+}
+
+func scanBaseUUID(base *Base, src any) error {
+	switch src := src.(type) {
+	case nil:
+		*base = Base{}
+
+		return nil
+	case string:
+		return parseUUID(base, []byte(src))
+	case []byte:
+		if len(src) == baseLen {
+			copy(base[:], src)
+
+			return nil
+		}
+
+		return parseUUID(base, src)
+	default:
+		return fmt.Errorf("%w: invalid scan source: %T", ErrFailedParse, src)
+	}
+}
+
+// formatUUID returns the [Base] formatted as a UUID string.
+func formatUUID(base Base) string {
+	dst := make([]byte, uuidLen)
+
+	hex.Encode(dst[0:8], base[0:4])
+	dst[8] = '-'
+	hex.Encode(dst[9:13], base[4:6])
+	dst[13] = '-'
+	hex.Encode(dst[14:18], base[6:8])
+	dst[18] = '-'
+	hex.Encode(dst[19:23], base[8:10])
+	dst[23] = '-'
+	hex.Encode(dst[24:], base[10:])
+
+	return string(dst)
+}
+
+// parseUUID parses the [Base] from the UUID string.
+func parseUUID(base *Base, src []byte) error {
+	if len(src) == 0 {
+		*base = Base{}
+
+		return nil
+	} else if len(src) != uuidLen || src[8] != '-' || src[13] != '-' || src[18] != '-' || src[23] != '-' {
+		return fmt.Errorf("%w: invalid uuid: %q", ErrFailedParse, src)
+	}
+
+	var (
+		dst  Base
+		hexs = [][2]int{{0, 8}, {9, 13}, {14, 18}, {19, 23}, {24, uuidLen}}
+		off  int
+	)
+
+	for _, r := range hexs {
+		n, err := hex.Decode(dst[off:], src[r[0]:r[1]])
+		if err != nil {
+			return fmt.Errorf("%w: invalid uuid: %w", ErrFailedParse, err)
+		}
+
+		off += n
+	}
+
+	*base = dst
+
+	return nil
+}
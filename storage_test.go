@@ -0,0 +1,271 @@
+package nid_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.wamod.dev/nid"
+)
+
+func TestWithStorage(t *testing.T) {
+	tt := []struct {
+		name    string
+		storage nid.Storage
+		wantErr bool
+	}{
+		{
+			name:    "text",
+			storage: nid.StorageText,
+		},
+		{
+			name:    "uuid",
+			storage: nid.StorageUUID,
+		},
+		{
+			name:    "unknown",
+			storage: nid.Storage(42),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			idn, err := nid.NewNaming("book", nid.WithStorage(tc.storage))
+			if tc.wantErr == (err == nil) {
+				t.Fatalf("NewNaming(WithStorage()) err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if err == nil && idn.Storage() != tc.storage {
+				t.Errorf("Naming.Storage() = %s; want = %s", idn.Storage(), tc.storage)
+			}
+		})
+	}
+}
+
+func TestColumnValue(t *testing.T) {
+	id := nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+
+	tt := []struct {
+		name    string
+		storage nid.Storage
+		id      nid.NID
+		want    any
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			storage: nid.StorageBinary,
+			id:      nid.NID{},
+			want:    nil,
+		},
+		{
+			name:    "text",
+			storage: nid.StorageText,
+			id:      id,
+			want:    "book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:    "base",
+			storage: nid.StorageBase,
+			id:      id,
+			want:    "000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:    "binary",
+			storage: nid.StorageBinary,
+			id:      id,
+			want: []byte{
+				0x00, 0x00, 0x01, 0x93, 0x01, 0x92, 0xdc, 0x7f,
+				0x00, 0x45, 0x38, 0x15, 0x44, 0xbb, 0xf3, 0x4f,
+			},
+		},
+		{
+			name:    "uuid",
+			storage: nid.StorageUUID,
+			id:      id,
+			want:    "00000193-0192-dc7f-0045-381544bbf34f",
+		},
+		{
+			name:    "namespace_text",
+			storage: nid.StorageText,
+			id:      nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+			want:    "acme.book_000034o1ibe7u02570ak9evj9s",
+		},
+		{
+			name:    "namespace_base",
+			storage: nid.StorageBase,
+			id:      nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
+		{
+			name:    "namespace_binary",
+			storage: nid.StorageBinary,
+			id:      nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
+		{
+			name:    "namespace_uuid",
+			storage: nid.StorageUUID,
+			id:      nid.MustParse("acme.book_000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
+		{
+			name:    "other_name",
+			storage: nid.StorageBase,
+			id:      nid.MustParse("author_000034o1ibe7u02570ak9evj9s"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := nid.MustNaming("book", nid.WithStorage(tc.storage)).Column(&tc.id).Value()
+			if tc.wantErr == (err == nil) {
+				t.Errorf("Column.Value() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if !reflect.DeepEqual(value, tc.want) {
+				t.Errorf("Column.Value() = %v; want = %v", value, tc.want)
+			}
+		})
+	}
+}
+
+func TestColumnScan(t *testing.T) {
+	id := nid.MustParse("book_000034o1ibe7u02570ak9evj9s")
+
+	tt := []struct {
+		name    string
+		storage nid.Storage
+		src     any
+		want    nid.NID
+		wantErr bool
+	}{
+		{
+			name:    "nil",
+			storage: nid.StorageUUID,
+			src:     nil,
+			want:    nid.NID{},
+		},
+		{
+			name:    "text",
+			storage: nid.StorageText,
+			src:     "book_000034o1ibe7u02570ak9evj9s",
+			want:    id,
+		},
+		{
+			name:    "text_other_name",
+			storage: nid.StorageText,
+			src:     "author_000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "base",
+			storage: nid.StorageBase,
+			src:     []byte("000034o1ibe7u02570ak9evj9s"),
+			want:    id,
+		},
+		{
+			name:    "base_invalid",
+			storage: nid.StorageBase,
+			src:     "book_000034o1ibe7u02570ak9evj9s",
+			wantErr: true,
+		},
+		{
+			name:    "base_int64",
+			storage: nid.StorageBase,
+			src:     int64(123),
+			wantErr: true,
+		},
+		{
+			name:    "binary",
+			storage: nid.StorageBinary,
+			src: []byte{
+				0x00, 0x00, 0x01, 0x93, 0x01, 0x92, 0xdc, 0x7f,
+				0x00, 0x45, 0x38, 0x15, 0x44, 0xbb, 0xf3, 0x4f,
+			},
+			want: id,
+		},
+		{
+			name:    "binary_invalid_len",
+			storage: nid.StorageBinary,
+			src:     []byte{0x00, 0x00, 0x01, 0x93},
+			wantErr: true,
+		},
+		{
+			name:    "uuid",
+			storage: nid.StorageUUID,
+			src:     "00000193-0192-dc7f-0045-381544bbf34f",
+			want:    id,
+		},
+		{
+			name:    "uuid_bytes",
+			storage: nid.StorageUUID,
+			src: []byte{
+				0x00, 0x00, 0x01, 0x93, 0x01, 0x92, 0xdc, 0x7f,
+				0x00, 0x45, 0x38, 0x15, 0x44, 0xbb, 0xf3, 0x4f,
+			},
+			want: id,
+		},
+		{
+			name:    "uuid_zeros",
+			storage: nid.StorageUUID,
+			src:     "00000000-0000-0000-0000-000000000000",
+			want:    nid.NID{},
+		},
+		{
+			name:    "uuid_no_dashes",
+			storage: nid.StorageUUID,
+			src:     "000001930192dc7f0045381544bbf34f",
+			wantErr: true,
+		},
+		{
+			name:    "uuid_invalid_hex",
+			storage: nid.StorageUUID,
+			src:     "0000019x-0192-dc7f-0045-381544bbf34f",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var id nid.NID
+
+			err := nid.MustNaming("book", nid.WithStorage(tc.storage)).Column(&id).Scan(tc.src)
+			if tc.wantErr == (err == nil) {
+				t.Errorf("Column.Scan() err = %v; wantErr = %v", err, tc.wantErr)
+			}
+
+			if id != tc.want {
+				t.Errorf("Column.Scan() = %v; want = %v", id, tc.want)
+			}
+		})
+	}
+}
+
+func TestColumnScopedRoundTrip(t *testing.T) {
+	idn := mustScope(nid.MustNaming("book", nid.WithStorage(nid.StorageBinary)), "acme")
+	id := idn.New()
+
+	value, err := idn.Column(&id).Value()
+	if err != nil {
+		t.Fatalf("Column.Value() unexpected err = %v", err)
+	}
+
+	var got nid.NID
+	if err := idn.Column(&got).Scan(value); err != nil || got != id {
+		t.Errorf("Column.Scan() = %v, %v; want = %v", got, err, id)
+	}
+}
+
+func TestColumnNil(t *testing.T) {
+	column := nid.MustNaming("book").Column(nil)
+
+	if value, err := column.Value(); value != nil || err != nil {
+		t.Errorf("Column.Value() = %v, %v; want = nil, nil", value, err)
+	}
+
+	if err := column.Scan("book_000034o1ibe7u02570ak9evj9s"); err == nil {
+		t.Errorf("Column.Scan() err = nil; want error")
+	}
+}